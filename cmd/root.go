@@ -15,6 +15,7 @@ func NewRootCmd() *cobra.Command {
 		Short:        "Scan your data stores for unencrypted personal data (PII)",
 		Long:         "Scan your data stores for unencrypted personal data (PII)",
 		SilenceUsage: true,
+		Args:         cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			showData, err := cmd.Flags().GetBool("show-data")
 			if err != nil {
@@ -49,6 +50,16 @@ func NewRootCmd() *cobra.Command {
 				return err
 			}
 
+			onlyCategory, err := cmd.Flags().GetString("only-category")
+			if err != nil {
+				return err
+			}
+
+			exceptCategory, err := cmd.Flags().GetString("except-category")
+			if err != nil {
+				return err
+			}
+
 			minCount, err := cmd.Flags().GetInt("min-count")
 			if err != nil {
 				return err
@@ -57,7 +68,25 @@ func NewRootCmd() *cobra.Command {
 				return fmt.Errorf("min-count must be positive")
 			}
 
-			pattern, err := cmd.Flags().GetString("pattern")
+			minConfidence, err := cmd.Flags().GetFloat64("min-confidence")
+			if err != nil {
+				return err
+			}
+			if minConfidence < 0 || minConfidence > 1 {
+				return fmt.Errorf("min-confidence must be between 0 and 1")
+			}
+
+			patterns, err := cmd.Flags().GetStringArray("pattern")
+			if err != nil {
+				return err
+			}
+
+			rulesFile, err := cmd.Flags().GetString("rules-file")
+			if err != nil {
+				return err
+			}
+
+			rulePack, err := cmd.Flags().GetString("rule-pack")
 			if err != nil {
 				return err
 			}
@@ -82,7 +111,7 @@ func NewRootCmd() *cobra.Command {
 			// 	return fmt.Errorf("Too many arguments")
 			// }
 
-			return internal.Main(args[0], showData, showAll, limit, processes, only, except, minCount, pattern, debug, format)
+			return internal.Main(args[0], showData, showAll, limit, processes, only, except, onlyCategory, exceptCategory, minCount, minConfidence, patterns, rulesFile, rulePack, debug, format)
 		},
 	}
 	cmd.PersistentFlags().Bool("show-data", false, "Show data")
@@ -91,11 +120,18 @@ func NewRootCmd() *cobra.Command {
 	cmd.PersistentFlags().Int("processes", 1, "Processes")
 	cmd.PersistentFlags().String("only", "", "Only certain rules")
 	cmd.PersistentFlags().String("except", "", "Except certain rules")
+	cmd.PersistentFlags().String("only-category", "", "Only certain categories of rules")
+	cmd.PersistentFlags().String("except-category", "", "Except certain categories of rules")
 	cmd.PersistentFlags().Int("min-count", 1, "Minimum rows/documents/lines for a match (experimental)")
-	cmd.PersistentFlags().String("pattern", "", "Custom pattern (experimental)")
+	cmd.PersistentFlags().Float64("min-confidence", 0, "Minimum confidence score for a match (0 to 1)")
+	cmd.PersistentFlags().StringArray("pattern", nil, "Custom pattern, optionally named (name=regex or name:confidence=regex)")
+	cmd.PersistentFlags().String("rules-file", "", "Custom rules file (YAML or JSON)")
+	cmd.PersistentFlags().String("rule-pack", "", "Additional rule packs (br, ca, de, es, eu, fr, in, phi, secrets, uk)")
 	cmd.PersistentFlags().Bool("debug", false, "Debug")
 	cmd.PersistentFlags().MarkHidden("debug")
 	cmd.PersistentFlags().String("format", "text", "Output format (experimental)")
+	cmd.AddCommand(newRulesCmd())
+	cmd.CompletionOptions.DisableDefaultCmd = true
 	return cmd
 }
 
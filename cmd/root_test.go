@@ -35,6 +35,19 @@ func TestFileCsvLocation(t *testing.T) {
 	checkFile(t, "location.csv", false)
 }
 
+func TestFileCoordinates(t *testing.T) {
+	stdout, _ := fileOutput("coordinates.json")
+	assert.Contains(t, stdout, "coordinates.json:1:51: found location data (1 line, confidence")
+}
+
+func TestFilePhones(t *testing.T) {
+	stdout, _ := fileOutput("phones.txt")
+	assert.Contains(t, stdout, "phones.txt:1:16: found phone numbers (2 lines, confidence 1.00, countries FR, GB)")
+
+	stdout, _ = captureOutput(func() { runCmd([]string{fileUrl("phones.txt"), "--format", "ndjson"}) })
+	assert.Contains(t, stdout, `"countries":["FR","GB"]`)
+}
+
 func TestFileGit(t *testing.T) {
 	stdout, _ := fileOutput("../.git")
 	assert.Contains(t, stdout, ".git/logs/HEAD:")
@@ -71,15 +84,29 @@ func TestFileZip(t *testing.T) {
 
 func TestFileMinCount(t *testing.T) {
 	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("min-count.txt"), "--min-count", "2"}) })
-	assert.Contains(t, stdout, "found emails (2 lines)")
+	assert.Contains(t, stdout, "found emails (2 lines, confidence")
 
 	_, stderr := captureOutput(func() { runCmd([]string{fileUrl("min-count.txt"), "--min-count", "3"}) })
 	assert.Contains(t, stderr, "No sensitive data found")
 }
 
+func TestFileLocation(t *testing.T) {
+	stdout, _ := fileOutput("notes.txt")
+	assert.Contains(t, stdout, "notes.txt:2:29: found SSNs")
+
+	stdout, _ = captureOutput(func() { runCmd([]string{fileUrl("notes.txt"), "--format", "ndjson"}) })
+	assert.Contains(t, stdout, `"line":2,"column":29,"offset":58`)
+
+	stdout, _ = captureOutput(func() { runCmd([]string{fileUrl("notes.txt"), "--format", "ndjson", "--show-data"}) })
+	assert.Contains(t, stdout, `"locations":[{"value":"123456789","line":2,"column":29,"offset":58}]`)
+
+	stdout, _ = fileOutput("email.zip")
+	assert.Contains(t, stdout, "email.zip: found emails")
+}
+
 func TestFileLineCount(t *testing.T) {
 	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("min-count.txt"), "--show-data"}) })
-	assert.Contains(t, stdout, "found emails (2 lines)")
+	assert.Contains(t, stdout, "found emails (2 lines, confidence")
 	assert.Contains(t, stdout, "test1@example.org, test2@example.org, test3@example.org")
 }
 
@@ -242,7 +269,7 @@ func TestPostgres(t *testing.T) {
 
 	stdout, _ := checkSql(t, "postgres://localhost/pdscan_test?sslmode=disable")
 	assert.Contains(t, stdout, "users.mac:")
-	assert.Contains(t, stdout, "users.emails: found emails (1 row)")
+	assert.Contains(t, stdout, "users.emails: found emails (1 row, confidence")
 	assert.Contains(t, stdout, "users.settings:")
 	assert.Contains(t, stdout, "users.settings2:")
 	assert.Contains(t, stdout, "users.settings3:")
@@ -394,22 +421,195 @@ func TestBadScheme(t *testing.T) {
 
 func TestPattern(t *testing.T) {
 	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("min-count.txt"), "--pattern", `\stest[12]`, "--show-data"}) })
-	assert.Contains(t, stdout, "found pattern (1 line)")
+	assert.Contains(t, stdout, "found pattern (1 line, confidence")
 	assert.NotContains(t, stdout, "found email")
 	assert.NotContains(t, stdout, "test1")
 	assert.Contains(t, stdout, "test2")
 	assert.NotContains(t, stdout, "test3")
 }
 
+func TestPatternEquals(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("config.txt"), "--pattern", `password\=\w+`, "--show-data"}) })
+	assert.Contains(t, stdout, "found pattern (1 line, confidence")
+	assert.Contains(t, stdout, "password=hunter2")
+	assert.NotContains(t, stdout, "username")
+}
+
+func TestPatternNamed(t *testing.T) {
+	stdout, _ := captureOutput(func() {
+		runCmd([]string{fileUrl("customer.txt"), "--pattern", `customer_id=CUST-\d{6}`, "--pattern", `order:low=(?i:order placed)`, "--show-all"})
+	})
+	assert.Contains(t, stdout, "found customer_id (1 line, confidence 1.00)")
+	assert.Contains(t, stdout, "found order (1 line, low confidence 0.35)")
+	assert.Contains(t, stdout, "found emails (1 line, confidence")
+
+	stdout, _ = captureOutput(func() {
+		runCmd([]string{fileUrl("customer.txt"), "--pattern", `customer_id=CUST-\d{6}`, "--only", "customer_id", "--format", "ndjson"})
+	})
+	assert.Contains(t, stdout, `"name":"customer_id"`)
+	assert.NotContains(t, stdout, `"name":"email"`)
+}
+
+func TestPatternDuplicate(t *testing.T) {
+	err := runCmd([]string{fileUrl("customer.txt"), "--pattern", `email=\w+@\w+`})
+	assert.Contains(t, err.Error(), "Duplicate rule: email")
+}
+
 func TestBadPattern(t *testing.T) {
 	err := runCmd([]string{fileUrl("min-count.txt"), "--pattern", `\e`})
 	assert.Contains(t, err.Error(), "error parsing regexp: invalid escape sequence: `\\e`")
 }
 
+func TestRulesFile(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("customer.txt"), "--rules-file", "../testdata/rules.yml"}) })
+	assert.Contains(t, stdout, "found customer IDs (1 line, confidence")
+	assert.Contains(t, stdout, "found emails (1 line, confidence")
+}
+
+func TestRulesFileJson(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("customer.txt"), "--rules-file", "../testdata/rules.json"}) })
+	assert.Contains(t, stdout, "found customer IDs (1 line, confidence")
+}
+
+func TestRulesFileOnly(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("customer.txt"), "--rules-file", "../testdata/rules.yml", "--only", "customer_id"}) })
+	assert.Contains(t, stdout, "found customer IDs (1 line, confidence")
+	assert.NotContains(t, stdout, "found emails")
+
+	stdout, _ = captureOutput(func() { runCmd([]string{fileUrl("customer.txt"), "--rules-file", "../testdata/rules.yml", "--except", "customer_id"}) })
+	assert.NotContains(t, stdout, "found customer IDs")
+	assert.Contains(t, stdout, "found emails (1 line, confidence")
+}
+
+func TestRulesFileAllowlist(t *testing.T) {
+	_, stderr := captureOutput(func() { runCmd([]string{fileUrl("email.txt"), "--rules-file", "../testdata/allowlist.yml"}) })
+	assert.Contains(t, stderr, "No sensitive data found")
+
+	_, stderr = captureOutput(func() { runCmd([]string{fileUrl("customer.txt"), "--rules-file", "../testdata/allowlist.yml"}) })
+	assert.Contains(t, stderr, "No sensitive data found")
+}
+
+func TestRulesFileMissing(t *testing.T) {
+	err := runCmd([]string{fileUrl("customer.txt"), "--rules-file", "../testdata/missing.yml"})
+	assert.Contains(t, err.Error(), "no such file or directory")
+}
+
+func TestRulesFileInvalid(t *testing.T) {
+	err := runCmd([]string{fileUrl("customer.txt"), "--rules-file", "../testdata/email.txt"})
+	assert.Contains(t, err.Error(), "Invalid rules file")
+}
+
+func TestRulePack(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("iban.txt"), "--rule-pack", "uk"}) })
+	assert.Contains(t, stdout, "found IBANs (1 line, confidence")
+
+	stdout, _ = captureOutput(func() { runCmd([]string{fileUrl("iban.txt"), "--rule-pack", "uk", "--except", "iban"}) })
+	assert.NotContains(t, stdout, "found IBANs")
+
+	_, stderr := captureOutput(func() { runCmd([]string{fileUrl("iban.txt")}) })
+	assert.Contains(t, stderr, "No sensitive data found")
+}
+
+func TestOnlyCategory(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("secrets.txt"), "--rule-pack", "secrets", "--only-category", "secret", "--format", "ndjson"}) })
+	assert.Contains(t, stdout, `"name":"database_url"`)
+	assert.Contains(t, stdout, `"severity":"critical"`)
+	assert.Contains(t, stdout, `"tags":[]`)
+
+	_, stderr := captureOutput(func() { runCmd([]string{fileUrl("secrets.txt"), "--rule-pack", "secrets", "--except-category", "secret"}) })
+	assert.Contains(t, stderr, "No sensitive data found")
+}
+
+func TestBadOnlyCategory(t *testing.T) {
+	err := runCmd([]string{fileUrl("email.txt"), "--only-category", "bad"})
+	assert.Contains(t, err.Error(), "Invalid category: bad")
+	assert.Contains(t, err.Error(), "Valid categories are location, pci, phi, pii, secret")
+}
+
+func TestRulePackSecrets(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("secrets.txt"), "--rule-pack", "secrets"}) })
+	assert.Contains(t, stdout, "found AWS access keys (secret, 1 line, confidence")
+	assert.Contains(t, stdout, "found database connection strings (secret, 1 line, confidence")
+
+	stdout, _ = captureOutput(func() { runCmd([]string{fileUrl("secrets.txt"), "--rule-pack", "secrets", "--format", "ndjson"}) })
+	assert.Contains(t, stdout, `"name":"aws_access_key"`)
+	assert.Contains(t, stdout, `"category":"secret"`)
+
+	stdout, _ = captureOutput(func() { runCmd([]string{fileUrl("url.txt"), "--rule-pack", "secrets"}) })
+	assert.Contains(t, stdout, "found URLs with passwords (secret, 1 line, confidence")
+	assert.NotContains(t, stdout, "found emails")
+}
+
+func TestBadRulePack(t *testing.T) {
+	err := runCmd([]string{fileUrl("iban.txt"), "--rule-pack", "xx"})
+	assert.Contains(t, err.Error(), "Invalid rule pack: xx")
+}
+
+func TestRulesList(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{"rules", "list"}) })
+	assert.Contains(t, stdout, "KIND")
+	assert.Regexp(t, `regex +email +emails +high +pii +-`, stdout)
+	assert.Regexp(t, `multi_name +full_name +full names +medium +pii +firstname, fname, givenname \+ lastname`, stdout)
+	assert.NotContains(t, stdout, "npi")
+
+	stdout, _ = captureOutput(func() { runCmd([]string{"rules", "list", "--rule-pack", "phi", "--only-category", "phi"}) })
+	assert.Contains(t, stdout, "npi")
+	assert.NotContains(t, stdout, "email")
+}
+
+func TestRulesExplain(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{"rules", "explain", "ssn"}) })
+	assert.Contains(t, stdout, "kind:         regex")
+	assert.Contains(t, stdout, "kind:         keyword")
+	assert.Contains(t, stdout, "validator:    validSSN")
+	assert.Contains(t, stdout, "distance:     20")
+
+	stdout, _ = captureOutput(func() { runCmd([]string{"rules", "explain", "customer_id", "--rules-file", "../testdata/rules.yml"}) })
+	assert.Contains(t, stdout, "display name: customer IDs")
+	assert.Contains(t, stdout, "pattern:")
+
+	// full dictionary
+	stdout, _ = captureOutput(func() { runCmd([]string{"rules", "explain", "surname"}) })
+	assert.Contains(t, stdout, "munoz")
+	assert.NotRegexp(t, `and \d+ more`, stdout)
+
+	err := runCmd([]string{"rules", "explain", "bad"})
+	assert.Contains(t, err.Error(), "Invalid rule: bad")
+}
+
+func TestRulesTest(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{"rules", "test", "ssn", "123-45-6789", "ssn 123456789", "000-12-3456", "123456789"}) })
+	assert.Contains(t, stdout, "123-45-6789\n  found SSNs (ssn, value match, confidence 1.00): 123-45-6789\n")
+	assert.Contains(t, stdout, "  found SSNs (ssn, value match, confidence 1.00, near ssn): 123456789\n")
+	assert.Contains(t, stdout, "  rejected 000-12-3456 (ssn: failed validSSN)\n")
+	assert.Contains(t, stdout, "  rejected 123456789 (ssn: no keyword)\n")
+
+	stdout, _ = captureOutput(func() { runCmd([]string{"rules", "test", "email,phone", "+33 1 23 45 67 89", "hello"}) })
+	assert.Contains(t, stdout, "  found phone numbers (phone, value match, confidence 1.00, country FR): +33 1 23 45 67 89\n")
+	assert.Contains(t, stdout, "hello\n  no match\n")
+}
+
+func TestRulesTestColumn(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{"rules", "test", "postal_code", "--column", "zip", "12345", "67890"}) })
+	assert.Contains(t, stdout, "zip\n  found postal codes (postal_code, name match, confidence")
+}
+
+func TestKeywords(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("notes.txt")}) })
+	assert.Contains(t, stdout, "found SSNs (1 line, confidence 0.87, near ssn)")
+
+	stdout, _ = captureOutput(func() { runCmd([]string{fileUrl("notes.txt"), "--format", "ndjson"}) })
+	assert.Contains(t, stdout, `"keywords":["ssn"]`)
+}
+
 func TestFormatNdjson(t *testing.T) {
 	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("email.txt"), "--format", "ndjson"}) })
 	assert.Contains(t, stdout, `"name":"email"`)
 	assert.Contains(t, stdout, `"confidence":"high"`)
+	assert.Contains(t, stdout, `"score":1`)
+	assert.Contains(t, stdout, `"category":"pii"`)
+	assert.Contains(t, stdout, `"severity":"medium"`)
+	assert.Contains(t, stdout, `"tags":["GDPR","CCPA"]`)
 }
 
 func TestFormatNdjsonShowData(t *testing.T) {
@@ -423,6 +623,19 @@ func TestFormatNdjsonShowAll(t *testing.T) {
 	assert.Contains(t, stdout, `"confidence":"high"`)
 }
 
+func TestMinConfidence(t *testing.T) {
+	stdout, _ := captureOutput(func() { runCmd([]string{fileUrl("notes.txt"), "--min-confidence", "0.8"}) })
+	assert.Contains(t, stdout, "found SSNs")
+
+	_, stderr := captureOutput(func() { runCmd([]string{fileUrl("notes.txt"), "--min-confidence", "0.9"}) })
+	assert.Contains(t, stderr, "No sensitive data found")
+}
+
+func TestBadMinConfidence(t *testing.T) {
+	err := runCmd([]string{fileUrl("email.txt"), "--min-confidence", "2"})
+	assert.Contains(t, err.Error(), "min-confidence must be between 0 and 1")
+}
+
 func TestBadFormat(t *testing.T) {
 	err := runCmd([]string{fileUrl("email.txt"), "--format", "bad"})
 	assert.Contains(t, err.Error(), "Invalid format: bad")
@@ -551,7 +764,7 @@ func checkDocument(t *testing.T, urlStr string) (string, string) {
 	assert.Contains(t, stdout, "users.access_token:")
 
 	// arrays
-	assert.Contains(t, stdout, "users.emails: found emails (1 document)")
+	assert.Contains(t, stdout, "users.emails: found emails (1 document, confidence")
 	assert.Contains(t, stdout, "first@example.org")
 	assert.Contains(t, stdout, "second@example.org")
 
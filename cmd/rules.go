@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/ankane/pdscan/internal"
+	"github.com/spf13/cobra"
+)
+
+func newRulesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rules",
+		Short: "List, explain, and test rules",
+		Long:  "List, explain, and test rules (including rule packs, rules files, and patterns)",
+	}
+	cmd.AddCommand(newRulesListCmd())
+	cmd.AddCommand(newRulesExplainCmd())
+	cmd.AddCommand(newRulesTestCmd())
+	return cmd
+}
+
+func newRulesListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List rules",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := rulesOpts(cmd)
+			if err != nil {
+				return err
+			}
+
+			return internal.ListRules(opts)
+		},
+	}
+}
+
+func newRulesExplainCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "explain <name>",
+		Short: "Show the pattern, columns, or dictionary for a rule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := rulesOpts(cmd)
+			if err != nil {
+				return err
+			}
+
+			return internal.ExplainRule(args[0], opts)
+		},
+	}
+}
+
+func newRulesTestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test <name>[,<name>...] [value...]",
+		Short: "Check values against rules (reads lines from stdin without values)",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := rulesOpts(cmd)
+			if err != nil {
+				return err
+			}
+
+			column, err := cmd.Flags().GetString("column")
+			if err != nil {
+				return err
+			}
+
+			values := args[1:]
+			if len(values) == 0 {
+				scanner := bufio.NewScanner(cmd.InOrStdin())
+				for scanner.Scan() {
+					if strings.TrimSpace(scanner.Text()) != "" {
+						values = append(values, scanner.Text())
+					}
+				}
+				if err := scanner.Err(); err != nil {
+					return err
+				}
+			}
+
+			return internal.TestRules(args[0], values, column, opts)
+		},
+	}
+	cmd.Flags().String("column", "", "Check values together as a column with this name (for name rules)")
+	return cmd
+}
+
+// rule options are persistent flags on the root command
+func rulesOpts(cmd *cobra.Command) (internal.RulesOpts, error) {
+	opts := internal.RulesOpts{}
+
+	var err error
+	opts.Only, err = cmd.Flags().GetString("only")
+	if err != nil {
+		return opts, err
+	}
+
+	opts.Except, err = cmd.Flags().GetString("except")
+	if err != nil {
+		return opts, err
+	}
+
+	opts.OnlyCategory, err = cmd.Flags().GetString("only-category")
+	if err != nil {
+		return opts, err
+	}
+
+	opts.ExceptCategory, err = cmd.Flags().GetString("except-category")
+	if err != nil {
+		return opts, err
+	}
+
+	opts.Patterns, err = cmd.Flags().GetStringArray("pattern")
+	if err != nil {
+		return opts, err
+	}
+
+	opts.RulesFile, err = cmd.Flags().GetString("rules-file")
+	if err != nil {
+		return opts, err
+	}
+
+	opts.RulePack, err = cmd.Flags().GetString("rule-pack")
+	if err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
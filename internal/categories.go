@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// every kind of rule has a category, severity, and tags
+// rules without a category are personal data, and rules without
+// a severity or tags use the defaults for their category
+var categories = []string{"location", "pci", "phi", "pii", "secret"}
+
+var severities = []string{"low", "medium", "high", "critical"}
+
+// severity and regulatory tags for rules that don't set their own
+var categorySeverities = map[string]string{
+	"location": "medium",
+	"pci":      "high",
+	"phi":      "high",
+	"pii":      "medium",
+	"secret":   "high",
+}
+
+var categoryTags = map[string][]string{
+	"location": []string{"GDPR", "CCPA"},
+	"pci":      []string{"PCI-DSS"},
+	"phi":      []string{"HIPAA"},
+	"pii":      []string{"GDPR", "CCPA"},
+	"secret":   []string{},
+}
+
+func ruleCategory(category string) string {
+	if category == "" {
+		return "pii"
+	}
+	return category
+}
+
+func ruleSeverity(category string, severity string) string {
+	if severity == "" {
+		return categorySeverities[ruleCategory(category)]
+	}
+	return severity
+}
+
+func ruleTags(category string, tags []string) []string {
+	if tags == nil {
+		return categoryTags[ruleCategory(category)]
+	}
+	return tags
+}
+
+func validCategory(category string) bool {
+	for _, c := range categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+func validSeverity(severity string) bool {
+	for _, s := range severities {
+		if s == severity {
+			return true
+		}
+	}
+	return false
+}
+
+func (match *ruleMatch) setCategoryDefaults() {
+	match.Severity = ruleSeverity(match.Category, match.Severity)
+	match.Tags = ruleTags(match.Category, match.Tags)
+	match.Category = ruleCategory(match.Category)
+}
+
+// rule names for each category, for --only-category and --except-category
+func makeCategoryNames(matchConfig *MatchConfig) map[string][]string {
+	categoryNames := make(map[string][]string)
+	add := func(name string, category string) {
+		category = ruleCategory(category)
+		categoryNames[category] = append(categoryNames[category], name)
+	}
+
+	for _, rule := range matchConfig.RegexRules {
+		add(rule.Name, rule.Category)
+	}
+	for _, rule := range matchConfig.NameRules {
+		add(rule.Name, rule.Category)
+	}
+	for _, rule := range matchConfig.MultiNameRules {
+		add(rule.Name, rule.Category)
+	}
+	for _, rule := range matchConfig.TokenRules {
+		add(rule.Name, rule.Category)
+	}
+	for _, rule := range matchConfig.EntropyRules {
+		add(rule.Name, rule.Category)
+	}
+	for _, rule := range matchConfig.KeywordRules {
+		add(rule.Name, rule.Category)
+	}
+	return categoryNames
+}
+
+// rules are kept or removed by name, so rules with the same name
+// should have the same category
+func updateCategories(matchConfig *MatchConfig, value string, except bool) error {
+	categoryNames := makeCategoryNames(matchConfig)
+
+	names := make(map[string]bool)
+	for _, category := range strings.Split(value, ",") {
+		category = strings.ToLower(category)
+		if !validCategory(category) {
+			arr := append([]string{}, categories...)
+			sort.Strings(arr)
+			return fmt.Errorf("Invalid category: %s\nValid categories are %s", category, strings.Join(arr, ", "))
+		}
+		for _, name := range categoryNames[category] {
+			names[name] = true
+		}
+	}
+
+	filterRules(matchConfig, names, except)
+	return nil
+}
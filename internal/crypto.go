@@ -0,0 +1,164 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Bitcoin, Litecoin, Dogecoin, and Tron base58check addresses,
+// Bitcoin and Litecoin bech32 addresses, and Ethereum addresses
+// chains without a checksum (like Solana) are not detected
+var walletAddressRegex = regexp.MustCompile(`\b[13LMDT][1-9A-HJ-NP-Za-km-z]{25,34}\b|\b(?i:bc|ltc)1(?i:[02-9ac-hj-np-z]{11,71})\b|\b0x[0-9a-fA-F]{40}\b`)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// version bytes for pay-to-pubkey-hash and pay-to-script-hash addresses
+var base58Versions = map[byte]bool{
+	0x00: true, // bitcoin 1
+	0x05: true, // bitcoin 3
+	0x30: true, // litecoin L
+	0x32: true, // litecoin M
+	0x1e: true, // dogecoin D
+	0x41: true, // tron T
+}
+
+func base58Decode(v string) []byte {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i := 0; i < len(v); i++ {
+		d := strings.IndexByte(base58Alphabet, v[i])
+		if d == -1 {
+			return nil
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+
+	// leading ones are leading zero bytes
+	zeros := 0
+	for zeros < len(v) && v[zeros] == '1' {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...)
+}
+
+// the last 4 bytes are the start of a double SHA-256 of the rest
+// https://en.bitcoin.it/wiki/Base58Check_encoding
+func validBase58Check(v string) bool {
+	decoded := base58Decode(v)
+	if len(decoded) != 25 || !base58Versions[decoded[0]] {
+		return false
+	}
+
+	first := sha256.Sum256(decoded[:21])
+	second := sha256.Sum256(first[:])
+	return bytes.Equal(second[:4], decoded[21:])
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+func bech32Polymod(values []int) int {
+	generator := []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := 1
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ v
+		for i := 0; i < 5; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// bech32 for segwit v0 and bech32m for later versions (like taproot),
+// where the witness version is the first character after the separator
+// https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki
+// https://github.com/bitcoin/bips/blob/master/bip-0350.mediawiki
+func validBech32(v string) bool {
+	if strings.ToLower(v) != v && strings.ToUpper(v) != v {
+		return false
+	}
+	v = strings.ToLower(v)
+
+	sep := strings.LastIndexByte(v, '1')
+	if sep < 1 || len(v)-sep < 7 {
+		return false
+	}
+
+	values := []int{}
+	hrp := v[:sep]
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, int(hrp[i]>>5))
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, int(hrp[i]&31))
+	}
+	for i := sep + 1; i < len(v); i++ {
+		d := strings.IndexByte(bech32Charset, v[i])
+		if d == -1 {
+			return false
+		}
+		values = append(values, d)
+	}
+
+	polymod := bech32Polymod(values)
+	if values[2*len(hrp)+1] == 0 {
+		return polymod == 1
+	}
+	return polymod == 0x2bc830a3
+}
+
+// mixed-case addresses have an EIP-55 checksum, where letters are uppercase
+// when the matching nibble of the Keccak-256 hash of the lowercase address is 8 or more
+// https://eips.ethereum.org/EIPS/eip-55
+func validEIP55(v string) bool {
+	address := v[2:]
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(strings.ToLower(address)))
+	digest := hex.EncodeToString(hash.Sum(nil))
+
+	for i := 0; i < len(address); i++ {
+		c := address[i]
+		if c >= 'a' && c <= 'f' && digest[i] >= '8' {
+			return false
+		}
+		if c >= 'A' && c <= 'F' && digest[i] < '8' {
+			return false
+		}
+	}
+	return true
+}
+
+func ethereumChecksummed(v string) bool {
+	address := v[2:]
+	return strings.ToLower(address) != address && strings.ToUpper(address) != address
+}
+
+func validWalletAddress(v string) bool {
+	v = strings.TrimSpace(v)
+	if strings.HasPrefix(v, "0x") {
+		return len(v) == 42 && (!ethereumChecksummed(v) || validEIP55(v))
+	}
+	lower := strings.ToLower(v)
+	if strings.HasPrefix(lower, "bc1") || strings.HasPrefix(lower, "ltc1") {
+		return validBech32(v)
+	}
+	return validBase58Check(v)
+}
+
+// Ethereum addresses without a checksum could be other hex values
+func walletAddressConfidence(v string) string {
+	if strings.HasPrefix(v, "0x") && !ethereumChecksummed(v) {
+		return ""
+	}
+	return "high"
+}
@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var isoDate = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})(?:[T ](\d{1,2}):(\d{2})(?::(\d{2})(?:\.(\d+))?)?(?:Z|[+-]\d{2}:?\d{2})?)?$`)
+
+// US (month first) and EU (day first) dates
+var localDate = regexp.MustCompile(`^(\d{1,2})([/.-])(\d{1,2})[/.-](\d{4})(?:\s+(\d{1,2}):(\d{2})(?::(\d{2}))?)?$`)
+
+// the time of day is checked but not returned
+func parseDate(v string) (time.Time, bool) {
+	var year, month, day int
+	var timeParts []string
+
+	if m := isoDate.FindStringSubmatch(v); m != nil {
+		year, _ = strconv.Atoi(m[1])
+		month, _ = strconv.Atoi(m[2])
+		day, _ = strconv.Atoi(m[3])
+		timeParts = m[4:]
+	} else if m := localDate.FindStringSubmatch(v); m != nil {
+		first, _ := strconv.Atoi(m[1])
+		second, _ := strconv.Atoi(m[3])
+		year, _ = strconv.Atoi(m[4])
+		// ambiguous dates like 04/12/1980 are read as US
+		if m[2] == "." || first > 12 {
+			day, month = first, second
+		} else {
+			month, day = first, second
+		}
+		timeParts = m[5:]
+	} else {
+		return time.Time{}, false
+	}
+
+	hour, _ := strconv.Atoi(timeParts[0])
+	minute, _ := strconv.Atoi(timeParts[1])
+	second, _ := strconv.Atoi(timeParts[2])
+	if hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, false
+	}
+
+	if month < 1 || month > 12 || day < 1 {
+		return time.Time{}, false
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	// reject overflow like February 30
+	if date.Day() != day {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func validDate(v string) bool {
+	_, ok := parseDate(v)
+	return ok
+}
+
+func validBirthDate(v string) bool {
+	return validDate(v) && plausibleBirthDates([]string{v})
+}
+
+// birth dates are in the past and within a lifetime, and most people
+// are older than dates set when records are created (like signups and orders)
+// a few dates outside a lifetime (like placeholders or typos) are allowed
+func plausibleBirthDates(values []string) bool {
+	now := time.Now()
+	oldest := now.AddDate(-120, 0, 0)
+	recent := now.AddDate(-10, 0, 0)
+
+	dateCount := 0
+	outsideCount := 0
+	recentCount := 0
+	for _, v := range values {
+		date, ok := parseDate(v)
+		if !ok {
+			continue
+		}
+		dateCount++
+		if date.After(now) || date.Before(oldest) {
+			outsideCount++
+		} else if date.After(recent) {
+			recentCount++
+		}
+	}
+	return outsideCount*10 <= dateCount && recentCount*2 <= len(values)
+}
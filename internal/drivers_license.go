@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+)
+
+// formats overlap between states and many are only digits, so numbers
+// are only checked in columns or text that mention a driver's license
+// states with any number of digits up to a maximum (AK, AL, DE, NC, and OR)
+// have no distinctive format, so they are left out instead of matching any number
+var driversLicenseFormats = map[string]*regexp.Regexp{
+	"AZ": regexp.MustCompile(`^(?:[A-Z]\d{8}|\d{9})$`),
+	"AR": regexp.MustCompile(`^\d{4,9}$`),
+	"CA": regexp.MustCompile(`^[A-Z]\d{7}$`),
+	"CO": regexp.MustCompile(`^(?:\d{9}|[A-Z]\d{3,6}|[A-Z]{2}\d{2,5})$`),
+	"CT": regexp.MustCompile(`^\d{9}$`),
+	"DC": regexp.MustCompile(`^(?:\d{7}|\d{9})$`),
+	"FL": regexp.MustCompile(`^[A-Z]\d{12}$`),
+	"GA": regexp.MustCompile(`^\d{7,9}$`),
+	"HI": regexp.MustCompile(`^(?:[A-Z]\d{8}|\d{9})$`),
+	"ID": regexp.MustCompile(`^(?:[A-Z]{2}\d{6}[A-Z]|\d{9})$`),
+	"IL": regexp.MustCompile(`^[A-Z]\d{11,12}$`),
+	"IN": regexp.MustCompile(`^(?:[A-Z]\d{9}|\d{9,10})$`),
+	"IA": regexp.MustCompile(`^(?:\d{9}|\d{3}[A-Z]{2}\d{4})$`),
+	"KS": regexp.MustCompile(`^(?:[A-Z]\d[A-Z]\d[A-Z]|[A-Z]\d{8}|\d{9})$`),
+	"KY": regexp.MustCompile(`^(?:[A-Z]\d{8,9}|\d{9})$`),
+	"LA": regexp.MustCompile(`^00\d{7}$`),
+	"ME": regexp.MustCompile(`^(?:\d{7,8}|\d{7}[A-Z])$`),
+	"MD": regexp.MustCompile(`^[A-Z]\d{12}$`),
+	"MA": regexp.MustCompile(`^(?:[A-Z]\d{8}|\d{9})$`),
+	"MI": regexp.MustCompile(`^(?:[A-Z]\d{10}|[A-Z]\d{12})$`),
+	"MN": regexp.MustCompile(`^[A-Z]\d{12}$`),
+	"MS": regexp.MustCompile(`^\d{9}$`),
+	"MO": regexp.MustCompile(`^(?:[A-Z]\d{5,9}|[A-Z]\d{6}R|\d{8}[A-Z]{2}|\d{9}[A-Z]?)$`),
+	"MT": regexp.MustCompile(`^(?:[A-Z]\d{8}|\d{9}|\d{13,14})$`),
+	"NE": regexp.MustCompile(`^[A-Z]\d{6,8}$`),
+	"NV": regexp.MustCompile(`^(?:\d{9,10}|\d{12}|X\d{8})$`),
+	"NH": regexp.MustCompile(`^\d{2}[A-Z]{3}\d{5}$`),
+	"NJ": regexp.MustCompile(`^[A-Z]\d{14}$`),
+	"NM": regexp.MustCompile(`^\d{8,9}$`),
+	"NY": regexp.MustCompile(`^(?:[A-Z]\d{7}|[A-Z]\d{18}|\d{8,9}|\d{16}|[A-Z]{8})$`),
+	"ND": regexp.MustCompile(`^(?:[A-Z]{3}\d{6}|\d{9})$`),
+	"OH": regexp.MustCompile(`^(?:[A-Z]\d{4,8}|[A-Z]{2}\d{3,7}|\d{8})$`),
+	"OK": regexp.MustCompile(`^(?:[A-Z]\d{9}|\d{9})$`),
+	"PA": regexp.MustCompile(`^\d{8}$`),
+	"RI": regexp.MustCompile(`^(?:\d{7}|[A-Z]\d{6})$`),
+	"SC": regexp.MustCompile(`^\d{5,11}$`),
+	"SD": regexp.MustCompile(`^(?:\d{6,10}|\d{12})$`),
+	"TN": regexp.MustCompile(`^\d{7,9}$`),
+	"TX": regexp.MustCompile(`^\d{7,8}$`),
+	"UT": regexp.MustCompile(`^\d{4,10}$`),
+	"VT": regexp.MustCompile(`^(?:\d{8}|\d{7}A)$`),
+	"VA": regexp.MustCompile(`^(?:[A-Z]\d{8,11}|\d{9})$`),
+	"WA": regexp.MustCompile(`^(?:[A-Z*]{7}\d{3}[A-Z\d]{2}|WDL[A-Z\d]{9})$`),
+	"WV": regexp.MustCompile(`^(?:\d{7}|[A-Z]{1,2}\d{5,6})$`),
+	"WI": regexp.MustCompile(`^[A-Z]\d{13}$`),
+	"WY": regexp.MustCompile(`^\d{9,10}$`),
+}
+
+var driversLicenseSeparators = strings.NewReplacer(" ", "", "-", "")
+
+// matches the format of any state, since the state is usually a separate column
+// short numbers are skipped since they are more likely to be other values
+func validDriversLicense(v string) bool {
+	v = strings.ToUpper(driversLicenseSeparators.Replace(strings.TrimSpace(v)))
+	if len(v) < 5 || !hasDigit(v) {
+		return false
+	}
+	for _, format := range driversLicenseFormats {
+		if format.MatchString(v) {
+			return true
+		}
+	}
+	return false
+}
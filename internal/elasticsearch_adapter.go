@@ -142,11 +142,12 @@ func (a ElasticsearchAdapter) FetchTableData(table table, limit int) (*tableData
 	keyMap := make(map[string]int)
 
 	columnValues := make([][]string, 0)
+	rowIndexes := make([][]int, 0)
 
-	for _, hit := range r["hits"].(map[string]interface{})["hits"].([]interface{}) {
+	for row, hit := range r["hits"].(map[string]interface{})["hits"].([]interface{}) {
 		// TODO check _id
 		source := hit.(map[string]interface{})["_source"].(map[string]interface{})
-		keyMap, columnValues = scanSource(source, "", keyMap, columnValues)
+		keyMap, columnValues, rowIndexes = scanSource(source, "", row, keyMap, columnValues, rowIndexes)
 	}
 
 	columnNames := make([]string, len(keyMap))
@@ -154,10 +155,10 @@ func (a ElasticsearchAdapter) FetchTableData(table table, limit int) (*tableData
 		columnNames[i] = key
 	}
 
-	return &tableData{columnNames, columnValues}, nil
+	return &tableData{columnNames, columnValues, rowIndexes}, nil
 }
 
-func scanSource(object map[string]interface{}, prefix string, keyMap map[string]int, columnValues [][]string) (map[string]int, [][]string) {
+func scanSource(object map[string]interface{}, prefix string, row int, keyMap map[string]int, columnValues [][]string, rowIndexes [][]int) (map[string]int, [][]string, [][]int) {
 	for key, val := range object {
 		key = prefix + key
 		i, ok := keyMap[key]
@@ -165,17 +166,18 @@ func scanSource(object map[string]interface{}, prefix string, keyMap map[string]
 			i = len(keyMap)
 			keyMap[key] = i
 			columnValues = append(columnValues, []string{})
+			rowIndexes = append(rowIndexes, []int{})
 		}
 
 		switch typedVal := val.(type) {
 		case map[string]interface{}:
-			keyMap, columnValues = scanSource(typedVal, key+".", keyMap, columnValues)
+			keyMap, columnValues, rowIndexes = scanSource(typedVal, key+".", row, keyMap, columnValues, rowIndexes)
 		case []interface{}:
 			values := []string{}
 			for _, av := range typedVal {
 				switch av2 := av.(type) {
 				case map[string]interface{}:
-					keyMap, columnValues = scanSource(av2, key+".", keyMap, columnValues)
+					keyMap, columnValues, rowIndexes = scanSource(av2, key+".", row, keyMap, columnValues, rowIndexes)
 				case string:
 					values = append(values, av2)
 				}
@@ -183,12 +185,14 @@ func scanSource(object map[string]interface{}, prefix string, keyMap map[string]
 			// add as single value for now for correct document count
 			if len(values) > 0 {
 				columnValues[i] = append(columnValues[i], strings.Join(values, ", "))
+				rowIndexes[i] = append(rowIndexes[i], row)
 			}
 		case string:
 			columnValues[i] = append(columnValues[i], typedVal)
+			rowIndexes[i] = append(rowIndexes[i], row)
 		}
 	}
-	return keyMap, columnValues
+	return keyMap, columnValues, rowIndexes
 }
 
 func checkResult(res *esapi.Response) error {
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"math"
+	"regexp"
+)
+
+// base64, base64url, and hex alphabets
+var entropyCandidate = regexp.MustCompile(`[A-Za-z0-9+/_-]+=*`)
+
+// hex strings and UUIDs are usually identifiers (primary keys, hashes)
+var hexValue = regexp.MustCompile(`^[0-9a-fA-F-]+$`)
+
+// random strings almost always have a letter next to a digit,
+// while words, identifiers, and paths (org/2006/main) rarely do
+var mixedAlphanumeric = regexp.MustCompile(`[A-Za-z]\d|\d[A-Za-z]`)
+
+// https://en.wikipedia.org/wiki/Entropy_(information_theory)
+func shannonEntropy(v string) float64 {
+	counts := make(map[rune]int)
+	length := 0
+	for _, c := range v {
+		counts[c]++
+		length++
+	}
+
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(length)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// substrings that look randomly generated
+func (rule entropyRule) findMatches(v string) []string {
+	matches := []string{}
+	for _, index := range rule.findMatchIndexes(v) {
+		matches = append(matches, v[index[0]:index[1]])
+	}
+	return matches
+}
+
+func (rule entropyRule) findMatchIndexes(v string) [][]int {
+	indexes := [][]int{}
+	for _, index := range entropyCandidate.FindAllStringIndex(v, -1) {
+		candidate := v[index[0]:index[1]]
+		if len(candidate) < rule.MinLength || hexValue.MatchString(candidate) {
+			continue
+		}
+
+		if !mixedAlphanumeric.MatchString(candidate) {
+			continue
+		}
+
+		if shannonEntropy(candidate) >= rule.MinEntropy {
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes
+}
+
+// entropy matches that are not part of a regex rule match,
+// so secrets with their own rule (like GitHub tokens) are only reported once
+func (a *MatchFinder) findEntropyMatches(rule entropyRule, v string) []string {
+	indexes := rule.findMatchIndexes(v)
+	if len(indexes) == 0 {
+		return []string{}
+	}
+
+	regexIndexes := [][]int{}
+	for _, regexRule := range a.matchConfig.RegexRules {
+		for _, index := range regexRule.Regex.FindAllStringIndex(v, -1) {
+			if regexRule.Validator == nil || regexRule.Validator(v[index[0]:index[1]]) {
+				regexIndexes = append(regexIndexes, index)
+			}
+		}
+	}
+
+	matches := []string{}
+	for _, index := range indexes {
+		overlaps := false
+		for _, regexIndex := range regexIndexes {
+			if index[0] < regexIndex[1] && regexIndex[0] < index[1] {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			matches = append(matches, v[index[0]:index[1]])
+		}
+	}
+	return matches
+}
@@ -10,12 +10,31 @@ import (
 	"github.com/h2non/filetype"
 )
 
-func findScannerMatches(reader io.Reader, matchFinder *MatchFinder) error {
+// lines in archives (including gzip) have no offset (-1)
+// since offsets in the file would not point to them
+func findScannerMatches(reader io.Reader, matchFinder *MatchFinder, archived bool) error {
 	scanner := bufio.NewScanner(reader)
+
+	// track the byte offset of each line, including line endings
+	offset := 0
+	lineOffset := 0
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		if token != nil {
+			lineOffset = offset
+		}
+		offset += advance
+		return advance, token, err
+	})
+
 	for scanner.Scan() {
 		v := scanner.Text()
 		// TODO pass archive file and line number in file
-		matchFinder.Scan(v, matchFinder.Count)
+		if archived {
+			matchFinder.Scan(v, matchFinder.Count, -1)
+		} else {
+			matchFinder.Scan(v, matchFinder.Count, lineOffset)
+		}
 		matchFinder.Count += 1
 	}
 	return nil
@@ -47,7 +66,7 @@ func processZip(file io.Reader, matchFinder *MatchFinder) error {
 		defer fileReader.Close()
 
 		// TODO capture specific file in archive
-		err = processFile(fileReader, matchFinder)
+		err = processFile(fileReader, matchFinder, true)
 		if err != nil {
 			return err
 		}
@@ -62,10 +81,10 @@ func processGzip(file io.Reader, matchFinder *MatchFinder) error {
 		return err
 	}
 
-	return findScannerMatches(gz, matchFinder)
+	return findScannerMatches(gz, matchFinder, true)
 }
 
-func processFile(file io.Reader, matchFinder *MatchFinder) error {
+func processFile(file io.Reader, matchFinder *MatchFinder, archived bool) error {
 	reader := bufio.NewReader(file)
 
 	// we only have to pass the file header = first 261 bytes
@@ -94,5 +113,5 @@ func processFile(file io.Reader, matchFinder *MatchFinder) error {
 		return processGzip(reader, matchFinder)
 	}
 
-	return findScannerMatches(reader, matchFinder)
+	return findScannerMatches(reader, matchFinder, archived)
 }
@@ -27,21 +27,43 @@ type TextFormatter struct{}
 func (f TextFormatter) PrintMatch(writer io.Writer, match matchInfo) error {
 	var description string
 	if match.MatchType == "name" {
-		description = fmt.Sprintf("possible %s (name match)", match.DisplayName)
+		str := "name match"
+		if match.Category == "secret" {
+			str = "secret, " + str
+		}
+		if match.Precision != "" {
+			str = str + ", " + match.Precision + " coordinates"
+		}
+		str = str + ", " + confidenceStr(match.ruleMatch)
+		description = fmt.Sprintf("possible %s (%s)", match.DisplayName, str)
 	} else {
-		str := pluralize(match.LineCount, match.RowStr)
-		if match.Confidence == "low" {
-			str = str + ", low confidence"
+		str := confidenceStr(match.ruleMatch)
+		// keys are a single value
+		if match.RowStr != "key" {
+			str = pluralize(match.LineCount, match.RowStr) + ", " + str
+		}
+		if match.Category == "secret" {
+			str = "secret, " + str
+		}
+		if len(match.Countries) == 1 {
+			str = str + ", country " + match.Countries[0]
+		} else if len(match.Countries) > 1 {
+			str = str + ", countries " + strings.Join(match.Countries, ", ")
 		}
-		if match.RowStr == "key" {
-			description = fmt.Sprintf("found %s", match.DisplayName)
-		} else {
-			description = fmt.Sprintf("found %s (%s)", match.DisplayName, str)
+		if len(match.Keywords) > 0 {
+			str = str + ", near " + strings.Join(match.Keywords, ", ")
 		}
+		description = fmt.Sprintf("found %s (%s)", match.DisplayName, str)
+	}
+
+	identifier := match.Identifier
+	// path:line:col for editors
+	if match.Location != nil {
+		identifier = fmt.Sprintf("%s:%d:%d", identifier, match.Location.Line, match.Location.Column)
 	}
 
 	yellow := color.New(color.FgYellow).SprintFunc()
-	fmt.Fprintf(writer, "%s %s\n", yellow(match.Identifier+":"), description)
+	fmt.Fprintf(writer, "%s %s\n", yellow(identifier+":"), description)
 
 	values := match.Values
 	if values != nil {
@@ -59,21 +81,52 @@ func (f TextFormatter) PrintMatch(writer io.Writer, match matchInfo) error {
 	return nil
 }
 
+func confidenceStr(match ruleMatch) string {
+	str := fmt.Sprintf("confidence %.2f", match.Score)
+	if match.Confidence == "low" {
+		str = "low " + str
+	}
+	return str
+}
+
 // JSONFormatter prints the result as a JSON object.
 type JSONFormatter struct{}
 
 type jsonEntry struct {
-	Identifier string `json:"identifier"`
-	Name       string `json:"name"`
-	MatchType  string `json:"match_type"`
-	Confidence string `json:"confidence"`
+	Identifier string   `json:"identifier"`
+	Name       string   `json:"name"`
+	MatchType  string   `json:"match_type"`
+	Confidence string   `json:"confidence"`
+	Score      float64  `json:"score"`
+	Category   string   `json:"category"`
+	Severity   string   `json:"severity"`
+	Tags       []string `json:"tags"`
+	Keywords   []string `json:"keywords,omitempty"`
+	Precision  string   `json:"precision,omitempty"`
+	Countries  []string `json:"countries,omitempty"`
+
+	*jsonLocation
+}
+
+// only for files
+type jsonLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Offset int `json:"offset"`
 }
 
 type jsonEntryWithMatches struct {
 	jsonEntry
 
-	Matches      []string `json:"matches"`
-	MatchesCount int      `json:"matches_count"`
+	Matches      []string            `json:"matches"`
+	MatchesCount int                 `json:"matches_count"`
+	Locations    []jsonValueLocation `json:"locations,omitempty"`
+}
+
+// each matched value in a file, in file order
+type jsonValueLocation struct {
+	Value string `json:"value"`
+	jsonLocation
 }
 
 func (f JSONFormatter) PrintMatch(writer io.Writer, match matchInfo) error {
@@ -84,14 +137,33 @@ func (f JSONFormatter) PrintMatch(writer io.Writer, match matchInfo) error {
 		Name:       match.RuleName,
 		MatchType:  match.MatchType,
 		Confidence: match.Confidence,
+		Score:      match.Score,
+		Category:   match.Category,
+		Severity:   match.Severity,
+		Tags:       match.Tags,
+		Keywords:   match.Keywords,
+		Precision:  match.Precision,
+		Countries:  match.Countries,
+	}
+	if match.Location != nil {
+		entry.jsonLocation = &jsonLocation{match.Location.Line, match.Location.Column, match.Location.Offset}
 	}
 
 	values := match.Values
 	if values != nil {
+		var locations []jsonValueLocation
+		for _, location := range match.Locations {
+			if len(locations) == 50 {
+				break
+			}
+			locations = append(locations, jsonValueLocation{location.Value, jsonLocation{location.Line, location.Column, location.Offset}})
+		}
+
 		return encoder.Encode(jsonEntryWithMatches{
 			jsonEntry:    entry,
 			Matches:      values,
 			MatchesCount: len(values),
+			Locations:    locations,
 		})
 	} else {
 		return encoder.Encode(entry)
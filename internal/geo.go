@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// latitude and longitude pairs with at least 4 decimal places (like 40.7128,-74.0060)
+// and GeoJSON positions, which are longitude first
+var coordinatesRegex = regexp.MustCompile(`"coordinates"\s*:\s*\[\s*-?\d{1,3}(?:\.\d+)?\s*,\s*-?\d{1,3}(?:\.\d+)?|-?\b\d{1,2}\.\d{4,}\s*,\s*-?\d{1,3}\.\d{4,}\b`)
+
+var geoJSONPrefix = regexp.MustCompile(`^"coordinates"\s*:\s*\[\s*`)
+
+func parseCoordinate(v string, limit float64) (float64, bool) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, false
+	}
+	return n, math.Abs(n) <= limit
+}
+
+func validCoordinates(lat string, lon string) bool {
+	_, latOk := parseCoordinate(lat, 90)
+	_, lonOk := parseCoordinate(lon, 180)
+	return latOk && lonOk
+}
+
+// returns latitude and longitude from a coordinates match
+func splitCoordinates(v string) (string, string, bool) {
+	geoJSON := geoJSONPrefix.MatchString(v)
+	parts := strings.Split(geoJSONPrefix.ReplaceAllString(v, ""), ",")
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	if geoJSON {
+		return parts[1], parts[0], true
+	}
+	return parts[0], parts[1], true
+}
+
+func validCoordinatesValue(v string) bool {
+	lat, lon, ok := splitCoordinates(v)
+	return ok && validCoordinates(lat, lon)
+}
+
+// GeoJSON is unambiguous, while number pairs could be something else
+func coordinatesConfidence(v string) string {
+	lat, lon, _ := splitCoordinates(v)
+	if geoJSONPrefix.MatchString(v) && coordinatePrecision([][]string{{lat, lon}}) == "precise" {
+		return "high"
+	}
+	return ""
+}
+
+// location columns must have coordinates in range
+// rows missing a latitude or longitude are skipped
+func validCoordinatesRow(row []string) bool {
+	if row[0] == "" || row[1] == "" {
+		return true
+	}
+	return validCoordinates(row[0], row[1])
+}
+
+// trailing zeros are ignored since fixed-precision columns pad with them
+func decimalPlaces(v string) int {
+	v = strings.TrimSpace(v)
+	i := strings.Index(v, ".")
+	if i == -1 {
+		return 0
+	}
+	return len(strings.TrimRight(v[i+1:], "0"))
+}
+
+// 2 decimal places is about 1 km (city-level) and 4 is about 10 m (a household)
+// uses the median of rows so a few rounded values don't change the result
+func coordinatePrecision(rows [][]string) string {
+	places := []int{}
+	for _, row := range rows {
+		if row[0] == "" || row[1] == "" {
+			continue
+		}
+		latPlaces := decimalPlaces(row[0])
+		lonPlaces := decimalPlaces(row[1])
+		if lonPlaces < latPlaces {
+			latPlaces = lonPlaces
+		}
+		places = append(places, latPlaces)
+	}
+	if len(places) == 0 {
+		return ""
+	}
+
+	sort.Ints(places)
+	median := places[len(places)/2]
+	if median >= 4 {
+		return "precise"
+	} else if median == 3 {
+		return "street-level"
+	}
+	return "city-level"
+}
+
+func precisionScore(precision string) float64 {
+	switch precision {
+	case "precise":
+		return 0.85
+	case "city-level":
+		return 0.25
+	}
+	return 0.55
+}
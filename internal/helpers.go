@@ -12,10 +12,28 @@ type ruleMatch struct {
 	RuleName    string
 	DisplayName string
 	Confidence  string
+	Score       float64
 	Identifier  string
 	MatchedData []string
 	MatchType   string
 	LineCount   int
+	Category    string
+	Severity    string
+	Tags        []string
+	Keywords    []string
+	Precision   string
+	Countries   []string
+	Location    *matchLocation
+	Locations   []matchLocation
+}
+
+// match in a file, with line and column starting at 1
+// column and offset are in bytes, like most editors and grep
+type matchLocation struct {
+	Value  string
+	Line   int
+	Column int
+	Offset int
 }
 
 type matchInfo struct {
@@ -45,15 +63,124 @@ func stringInSlice(a string, list []string) bool {
 	return false
 }
 
-func matchNameRule(name string, rules []nameRule) nameRule {
+var camelCaseBoundary = regexp.MustCompile(`([a-z\d])([A-Z])|([A-Z]+)([A-Z][a-z])`)
+var columnNameSeparator = regexp.MustCompile(`[^a-z\d]+`)
+
+// splits under_score, camelCase, kebab-case, and spaces
+// PhoneNo, phone_no, and phone-no all become phone and no
+func columnNameTokens(col string) []string {
+	col = camelCaseBoundary.ReplaceAllString(col, "${1}${3} ${2}${4}")
+	tokens := []string{}
+	for _, token := range columnNameSeparator.Split(strings.ToLower(col), -1) {
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// lowercased with separators removed, so a single list works for all styles
+func normalizeColumnName(col string) string {
+	return strings.Join(columnNameTokens(col), "")
+}
+
+// uses the last part for nested data
+func columnNameParts(col string) []string {
+	parts := strings.Split(col, ".")
+	return columnNameTokens(parts[len(parts)-1])
+}
+
+// path of the object containing the column for nested data (like address for address.city)
+func columnParent(col string) string {
+	if i := strings.LastIndex(col, "."); i != -1 {
+		return col[:i]
+	}
+	return ""
+}
+
+// tokens for the other columns in the same object
+func nearbyColumnParts(columnNames []string, index int) [][]string {
+	nearby := [][]string{}
+	parent := columnParent(columnNames[index])
+	for i, col := range columnNames {
+		if i != index && columnParent(col) == parent {
+			nearby = append(nearby, columnNameParts(col))
+		}
+	}
+	return nearby
+}
+
+// rules with RequiredColumns only match when a nearby column matches one of them
+func matchNameRule(tokens []string, nearby [][]string, rules []nameRule) nameRule {
+	name := strings.Join(tokens, "")
 	for _, rule := range rules {
-		if stringInSlice(name, rule.ColumnNames) {
+		if rule.matches(name, tokens) && rule.hasRequiredColumn(nearby) {
 			return rule
 		}
 	}
 	return nameRule{}
 }
 
+func (rule nameRule) hasRequiredColumn(nearby [][]string) bool {
+	if len(rule.RequiredColumns) == 0 {
+		return true
+	}
+
+	required := nameRule{ColumnNames: rule.RequiredColumns}
+	for _, tokens := range nearby {
+		if required.matches(strings.Join(tokens, ""), tokens) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule nameRule) matches(name string, tokens []string) bool {
+	for _, exclude := range rule.Exclude {
+		if strings.Contains(name, exclude) {
+			return false
+		}
+	}
+
+	for _, columnName := range rule.ColumnNames {
+		var matched bool
+		switch rule.MatchMode {
+		case "exact":
+			matched = name == columnName
+		case "prefix":
+			matched = strings.HasPrefix(name, columnName)
+		case "suffix":
+			matched = strings.HasSuffix(name, columnName)
+		case "contains":
+			matched = strings.Contains(name, columnName)
+		default:
+			matched = matchTokens(tokens, columnName)
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// one or more consecutive tokens form the column name,
+// so phone and phonenumber match home_phone_number but not iphone_model
+func matchTokens(tokens []string, columnName string) bool {
+	for i := range tokens {
+		joined := ""
+		for _, token := range tokens[i:] {
+			joined += token
+			if joined == columnName {
+				return true
+			}
+			if len(joined) >= len(columnName) {
+				break
+			}
+		}
+	}
+	return false
+}
+
 var space = regexp.MustCompile(`\s+`)
 var urlPassword = regexp.MustCompile(`((\/\/|%2F%2F)\S+(:|%3A))\S+(@|%40)`)
 
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+)
+
+type keywordMatch struct {
+	Value   string
+	Keyword string
+}
+
+var wordChar = regexp.MustCompile(`^\w$`)
+
+// case-insensitive and only matches whole words
+func keywordsRegex(keywords ...string) *regexp.Regexp {
+	patterns := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		pattern := regexp.QuoteMeta(keyword)
+		// word boundaries only work next to word characters (not for ss#)
+		if wordChar.MatchString(keyword[0:1]) {
+			pattern = `\b` + pattern
+		}
+		if wordChar.MatchString(keyword[len(keyword)-1:]) {
+			pattern = pattern + `\b`
+		}
+		patterns[i] = pattern
+	}
+	return regexp.MustCompile(`(?i)(?:` + strings.Join(patterns, "|") + `)`)
+}
+
+// values with a keyword before or after them within the rule's distance
+func (rule keywordRule) findMatches(v string) []keywordMatch {
+	matches := []keywordMatch{}
+
+	keywordIndexes := rule.Keywords.FindAllStringIndex(v, -1)
+	if len(keywordIndexes) == 0 {
+		return matches
+	}
+
+	for _, valueIndex := range rule.Regex.FindAllStringIndex(v, -1) {
+		value := v[valueIndex[0]:valueIndex[1]]
+		if rule.Validator != nil && !rule.Validator(value) {
+			continue
+		}
+
+		for _, keywordIndex := range keywordIndexes {
+			var distance int
+			if keywordIndex[1] <= valueIndex[0] {
+				distance = valueIndex[0] - keywordIndex[1]
+			} else if valueIndex[1] <= keywordIndex[0] {
+				distance = keywordIndex[0] - valueIndex[1]
+			} else {
+				// overlapping
+				continue
+			}
+
+			if distance <= rule.Distance {
+				keyword := strings.ToLower(v[keywordIndex[0]:keywordIndex[1]])
+				matches = append(matches, keywordMatch{value, keyword})
+				break
+			}
+		}
+	}
+	return matches
+}
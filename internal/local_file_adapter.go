@@ -49,5 +49,5 @@ func (a LocalFileAdapter) FindFileMatches(filename string, matchFinder *MatchFin
 	}
 	defer f.Close()
 
-	return processFile(f, matchFinder)
+	return processFile(f, matchFinder, false)
 }
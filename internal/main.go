@@ -27,7 +27,7 @@ type ScanOpts struct {
 	MatchConfig *MatchConfig
 }
 
-func Main(urlStr string, showData bool, showAll bool, limit int, processes int, only string, except string, minCount int, pattern string, debug bool, format string) error {
+func Main(urlStr string, showData bool, showAll bool, limit int, processes int, only string, except string, onlyCategory string, exceptCategory string, minCount int, minConfidence float64, patterns []string, rulesFile string, rulePack string, debug bool, format string) error {
 	runtime.GOMAXPROCS(processes)
 
 	formatter, found := Formatters[format]
@@ -40,31 +40,12 @@ func Main(urlStr string, showData bool, showAll bool, limit int, processes int,
 		return fmt.Errorf("Invalid format: %s\nValid formats are %s", format, strings.Join(arr, ", "))
 	}
 
-	matchConfig := NewMatchConfig()
-	if pattern != "" {
-		regex, err := regexp.Compile(pattern)
-		if err != nil {
-			return err
-		}
-		matchConfig.RegexRules = []regexRule{regexRule{Name: "pattern", DisplayName: "pattern", Confidence: "high", Regex: regex}}
-		matchConfig.NameRules = matchConfig.NameRules[:0]
-		matchConfig.MultiNameRules = matchConfig.MultiNameRules[:0]
-		matchConfig.TokenRules = matchConfig.TokenRules[:0]
-	} else {
-		if except != "" {
-			err := updateRules(&matchConfig, except, true)
-			if err != nil {
-				return err
-			}
-		}
-		if only != "" {
-			err := updateRules(&matchConfig, only, false)
-			if err != nil {
-				return err
-			}
-		}
+	matchConfig, err := loadMatchConfig(only, except, onlyCategory, exceptCategory, patterns, rulesFile, rulePack)
+	if err != nil {
+		return err
 	}
 	matchConfig.MinCount = minCount
+	matchConfig.MinConfidence = minConfidence
 
 	var adapter Adapter
 	if strings.HasPrefix(urlStr, "file://") {
@@ -110,6 +91,52 @@ func Main(urlStr string, showData bool, showAll bool, limit int, processes int,
 	return nil
 }
 
+// rules from the built-in rules, rule packs, rules file, and patterns, filtered by the options
+func loadMatchConfig(only string, except string, onlyCategory string, exceptCategory string, patterns []string, rulesFile string, rulePack string) (MatchConfig, error) {
+	matchConfig := NewMatchConfig()
+	if rulePack != "" {
+		err := addRulePacks(&matchConfig, rulePack)
+		if err != nil {
+			return matchConfig, err
+		}
+	}
+	if rulesFile != "" {
+		err := loadRulesFile(&matchConfig, rulesFile)
+		if err != nil {
+			return matchConfig, err
+		}
+	}
+	err := addPatterns(&matchConfig, patterns)
+	if err != nil {
+		return matchConfig, err
+	}
+	if except != "" {
+		err := updateRules(&matchConfig, except, true)
+		if err != nil {
+			return matchConfig, err
+		}
+	}
+	if only != "" {
+		err := updateRules(&matchConfig, only, false)
+		if err != nil {
+			return matchConfig, err
+		}
+	}
+	if exceptCategory != "" {
+		err := updateCategories(&matchConfig, exceptCategory, true)
+		if err != nil {
+			return matchConfig, err
+		}
+	}
+	if onlyCategory != "" {
+		err := updateCategories(&matchConfig, onlyCategory, false)
+		if err != nil {
+			return matchConfig, err
+		}
+	}
+	return matchConfig, nil
+}
+
 func scanDataStore(adapter DataStoreAdapter, scanOpts ScanOpts) ([]ruleMatch, error) {
 	err := adapter.Init(scanOpts.UrlStr)
 	if err != nil {
@@ -267,6 +294,11 @@ func updateRules(matchConfig *MatchConfig, value string, except bool) error {
 		names[name] = true
 	}
 
+	filterRules(matchConfig, names, except)
+	return nil
+}
+
+func filterRules(matchConfig *MatchConfig, names map[string]bool, except bool) {
 	regexRules := []regexRule{}
 	for _, rule := range matchConfig.RegexRules {
 		var keep bool
@@ -327,9 +359,170 @@ func updateRules(matchConfig *MatchConfig, value string, except bool) error {
 	}
 	matchConfig.TokenRules = tokenRules
 
+	entropyRules := []entropyRule{}
+	for _, rule := range matchConfig.EntropyRules {
+		var keep bool
+		if except {
+			keep = !names[rule.Name]
+		} else {
+			keep = names[rule.Name]
+		}
+
+		if keep {
+			entropyRules = append(entropyRules, rule)
+		}
+	}
+	matchConfig.EntropyRules = entropyRules
+
+	keywordRules := []keywordRule{}
+	for _, rule := range matchConfig.KeywordRules {
+		var keep bool
+		if except {
+			keep = !names[rule.Name]
+		} else {
+			keep = names[rule.Name]
+		}
+
+		if keep {
+			keywordRules = append(keywordRules, rule)
+		}
+	}
+	matchConfig.KeywordRules = keywordRules
+}
+
+func addRulePacks(matchConfig *MatchConfig, value string) error {
+	for _, name := range strings.Split(value, ",") {
+		pack, found := rulePacks[name]
+		if !found {
+			arr := make([]string, 0, len(rulePacks))
+			for k := range rulePacks {
+				arr = append(arr, k)
+			}
+			sort.Strings(arr)
+			return fmt.Errorf("Invalid rule pack: %s\nValid rule packs are %s", name, strings.Join(arr, ", "))
+		}
+
+		// packs can share rules (like IBANs), so skip ones already added
+		for _, rule := range pack.RegexRules {
+			if !hasRegexRule(matchConfig, rule.Name) {
+				matchConfig.RegexRules = append(matchConfig.RegexRules, rule)
+			}
+		}
+		for _, rule := range pack.NameRules {
+			if !hasNameRule(matchConfig, rule.Name) {
+				matchConfig.NameRules = append(matchConfig.NameRules, rule)
+			}
+		}
+		for _, rule := range pack.TokenRules {
+			found := false
+			for i, existing := range matchConfig.TokenRules {
+				if existing.Name == rule.Name {
+					// copy before updating since the default rules are shared
+					tokenRules := append([]tokenRule{}, matchConfig.TokenRules...)
+					tokenRules[i].Tokens = existing.Tokens.Union(rule.Tokens)
+					matchConfig.TokenRules = tokenRules
+					found = true
+				}
+			}
+			if !found {
+				matchConfig.TokenRules = append(matchConfig.TokenRules, rule)
+			}
+		}
+		for _, rule := range pack.EntropyRules {
+			if !hasEntropyRule(matchConfig, rule.Name) {
+				matchConfig.EntropyRules = append(matchConfig.EntropyRules, rule)
+			}
+		}
+	}
 	return nil
 }
 
+var patternName = regexp.MustCompile(`^([a-z][a-z0-9_]*)(?::(high|medium|low))?=`)
+
+// named patterns (name=regex or name:confidence=regex) are added to the other rules,
+// while an unnamed pattern replaces them
+// escape = in unnamed patterns that start like a name (like password\=\w+)
+func addPatterns(matchConfig *MatchConfig, patterns []string) error {
+	rules := []regexRule{}
+	replace := false
+	for _, pattern := range patterns {
+		name := "pattern"
+		confidence := "high"
+		regexStr := pattern
+		if m := patternName.FindStringSubmatch(pattern); m != nil {
+			name = m[1]
+			if m[2] != "" {
+				confidence = m[2]
+			}
+			regexStr = pattern[len(m[0]):]
+		} else {
+			replace = true
+		}
+		if regexStr == "" {
+			return fmt.Errorf("Invalid pattern: %s\nUse regex, name=regex, or name:confidence=regex", pattern)
+		}
+
+		regex, err := regexp.Compile(regexStr)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, regexRule{Name: name, DisplayName: name, Confidence: confidence, Regex: regex})
+	}
+
+	if replace {
+		matchConfig.RegexRules = []regexRule{}
+		matchConfig.NameRules = matchConfig.NameRules[:0]
+		matchConfig.MultiNameRules = matchConfig.MultiNameRules[:0]
+		matchConfig.TokenRules = matchConfig.TokenRules[:0]
+		matchConfig.EntropyRules = matchConfig.EntropyRules[:0]
+		matchConfig.KeywordRules = matchConfig.KeywordRules[:0]
+	}
+
+	for _, rule := range rules {
+		if hasRegexRule(matchConfig, rule.Name) {
+			return fmt.Errorf("Duplicate rule: %s", rule.Name)
+		}
+		matchConfig.RegexRules = append(matchConfig.RegexRules, rule)
+	}
+	return nil
+}
+
+func hasRegexRule(matchConfig *MatchConfig, name string) bool {
+	for _, rule := range matchConfig.RegexRules {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasNameRule(matchConfig *MatchConfig, name string) bool {
+	for _, rule := range matchConfig.NameRules {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasKeywordRule(matchConfig *MatchConfig, name string) bool {
+	for _, rule := range matchConfig.KeywordRules {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEntropyRule(matchConfig *MatchConfig, name string) bool {
+	for _, rule := range matchConfig.EntropyRules {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func makeValidNames(matchConfig *MatchConfig) map[string]bool {
 	validNames := make(map[string]bool)
 	for _, rule := range matchConfig.RegexRules {
@@ -344,5 +537,11 @@ func makeValidNames(matchConfig *MatchConfig) map[string]bool {
 	for _, rule := range matchConfig.TokenRules {
 		validNames[rule.Name] = true
 	}
+	for _, rule := range matchConfig.EntropyRules {
+		validNames[rule.Name] = true
+	}
+	for _, rule := range matchConfig.KeywordRules {
+		validNames[rule.Name] = true
+	}
 	return validNames
 }
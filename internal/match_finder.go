@@ -1,13 +1,17 @@
 package internal
 
 import (
-	"regexp"
+	"math"
+	"sort"
 	"strings"
 )
 
 type tableData struct {
 	ColumnNames  []string
 	ColumnValues [][]string
+	// document of each value for sources where documents can be missing fields
+	// (nil when values in the same position are from the same row)
+	RowIndexes [][]int
 }
 
 type MatchConfig struct {
@@ -15,7 +19,11 @@ type MatchConfig struct {
 	NameRules      []nameRule
 	MultiNameRules []multiNameRule
 	TokenRules     []tokenRule
+	EntropyRules   []entropyRule
+	KeywordRules   []keywordRule
+	Allowlists     []allowlist
 	MinCount       int
+	MinConfidence  float64
 }
 
 func NewMatchConfig() MatchConfig {
@@ -24,6 +32,9 @@ func NewMatchConfig() MatchConfig {
 		NameRules:      nameRules,
 		MultiNameRules: multiNameRules,
 		TokenRules:     tokenRules,
+		EntropyRules:   entropyRules,
+		KeywordRules:   keywordRules,
+		Allowlists:     allowlists,
 		MinCount:       1,
 	}
 }
@@ -31,21 +42,25 @@ func NewMatchConfig() MatchConfig {
 type MatchFinder struct {
 	MatchedValues [][]MatchLine
 	TokenValues   [][]MatchLine
+	EntropyValues [][]MatchLine
+	KeywordValues [][]MatchLine
 	Count         int
 	matchConfig   *MatchConfig
 }
 
+// Offset is the byte offset of the line in the file (-1 when not from a file)
 type MatchLine struct {
 	LineIndex int
+	Offset    int
 	Line      string
 }
 
-var tokenizer = regexp.MustCompile(`\W+`)
-
 func NewMatchFinder(matchConfig *MatchConfig) MatchFinder {
 	return MatchFinder{
 		make([][]MatchLine, len(matchConfig.RegexRules)),
 		make([][]MatchLine, len(matchConfig.TokenRules)),
+		make([][]MatchLine, len(matchConfig.EntropyRules)),
+		make([][]MatchLine, len(matchConfig.KeywordRules)),
 		0,
 		matchConfig,
 	}
@@ -53,21 +68,110 @@ func NewMatchFinder(matchConfig *MatchConfig) MatchFinder {
 
 // fast check for matches
 // extract values and index in a later step if needed (if --show-data is passed)
-func (a *MatchFinder) Scan(v string, index int) {
+func (a *MatchFinder) Scan(v string, index int, offset int) {
 	for i, rule := range a.matchConfig.RegexRules {
 		if rule.Regex.MatchString(v) {
-			a.MatchedValues[i] = append(a.MatchedValues[i], MatchLine{index, v})
+			a.MatchedValues[i] = append(a.MatchedValues[i], MatchLine{index, offset, v})
 		}
 	}
 
 	if len(a.matchConfig.TokenRules) > 0 {
-		tokens := tokenizer.Split(strings.ToLower(v), -1)
+		tokens := tokenize(v)
 		for i, rule := range a.matchConfig.TokenRules {
 			if anyMatches(rule, tokens) {
-				a.TokenValues[i] = append(a.TokenValues[i], MatchLine{index, v})
+				a.TokenValues[i] = append(a.TokenValues[i], MatchLine{index, offset, v})
+			}
+		}
+	}
+
+	for i, rule := range a.matchConfig.EntropyRules {
+		if len(a.findEntropyMatches(rule, v)) > 0 {
+			a.EntropyValues[i] = append(a.EntropyValues[i], MatchLine{index, offset, v})
+		}
+	}
+
+	for i, rule := range a.matchConfig.KeywordRules {
+		if rule.Keywords.MatchString(v) && rule.Regex.MatchString(v) {
+			a.KeywordValues[i] = append(a.KeywordValues[i], MatchLine{index, offset, v})
+		}
+	}
+}
+
+// all matches in a value that pass the rule's validator
+func (rule regexRule) findValidMatches(v string) []string {
+	matches := rule.Regex.FindAllString(v, -1)
+	if rule.Validator == nil {
+		return matches
+	}
+
+	validMatches := []string{}
+	for _, match := range matches {
+		if rule.Validator(match) {
+			validMatches = append(validMatches, match)
+		}
+	}
+	return validMatches
+}
+
+// values with a token from the rule and a different token from its paired rule
+func (a *MatchFinder) pairedTokenValues(rule tokenRule, values []string) []string {
+	var pairedRule *tokenRule
+	for i, other := range a.matchConfig.TokenRules {
+		if other.Name == rule.PairedRule {
+			pairedRule = &a.matchConfig.TokenRules[i]
+		}
+	}
+	if pairedRule == nil {
+		return nil
+	}
+
+	pairedValues := []string{}
+	for _, v := range values {
+		tokens := tokenize(v)
+		for i, token := range tokens {
+			if rule.Tokens.Contains(token) && anyMatchesExcept(*pairedRule, tokens, i) {
+				pairedValues = append(pairedValues, v)
+				break
 			}
 		}
 	}
+	return pairedValues
+}
+
+// tokens in a value that are in the rule
+func (rule tokenRule) findMatches(v string) []string {
+	matches := []string{}
+	for _, token := range tokenize(v) {
+		if rule.Tokens.Contains(token) {
+			matches = append(matches, token)
+		}
+	}
+	return matches
+}
+
+// lines with at least one match that is not allowlisted
+func (a *MatchFinder) removeAllowlistedLines(ruleName string, lines []string, findMatches func(string) []string) []string {
+	allowlists := a.ruleAllowlists(ruleName)
+	if len(allowlists) == 0 {
+		return lines
+	}
+
+	newLines := []string{}
+	for _, v := range lines {
+		if len(filterAllowlisted(allowlists, v, findMatches)) > 0 {
+			newLines = append(newLines, v)
+		}
+	}
+	return newLines
+}
+
+func anyMatchesExcept(rule tokenRule, values []string, skip int) bool {
+	for i, value := range values {
+		if i != skip && rule.Tokens.Contains(value) {
+			return true
+		}
+	}
+	return false
 }
 
 func anyMatches(rule tokenRule, values []string) bool {
@@ -81,7 +185,7 @@ func anyMatches(rule tokenRule, values []string) bool {
 
 func (a *MatchFinder) ScanValues(values []string) {
 	for i, v := range values {
-		a.Scan(v, i)
+		a.Scan(v, i, -1)
 	}
 	a.Count += len(values)
 }
@@ -89,10 +193,17 @@ func (a *MatchFinder) ScanValues(values []string) {
 func (a *MatchFinder) Clear() {
 	a.MatchedValues = make([][]MatchLine, len(a.matchConfig.RegexRules))
 	a.TokenValues = make([][]MatchLine, len(a.matchConfig.TokenRules))
+	a.EntropyValues = make([][]MatchLine, len(a.matchConfig.EntropyRules))
+	a.KeywordValues = make([][]MatchLine, len(a.matchConfig.KeywordRules))
 	a.Count = 0
 }
 
 func (a *MatchFinder) CheckMatches(colIdentifier string, onlyValues bool) []ruleMatch {
+	return a.finishMatches(a.checkValues(colIdentifier, onlyValues))
+}
+
+// matches with scores but without labels
+func (a *MatchFinder) checkValues(colIdentifier string, onlyValues bool) []ruleMatch {
 	matchList := []ruleMatch{}
 
 	matchedValues := a.MatchedValues
@@ -104,43 +215,91 @@ func (a *MatchFinder) CheckMatches(colIdentifier string, onlyValues bool) []rule
 			matchedData = append(matchedData, v.Line)
 		}
 
-		if rule.Name == "email" {
-			// filter out false positives with URL credentials
+		allowlists := a.ruleAllowlists(rule.Name)
+		findMatches := func(v string) []string {
+			return filterAllowlisted(allowlists, v, rule.findValidMatches)
+		}
+
+		passRate := validatorPassRate(rule, allowlists, matchedData)
+
+		if rule.Validator != nil || len(allowlists) > 0 {
+			// filter out values that fail checksums or range checks or are allowlisted
 			newMatchedData := matchedData
 			matchedData = []string{}
 			for _, v := range newMatchedData {
-				// replace urls and check for email match again
-				// TODO preserve offset
-				v2 := urlPassword.ReplaceAllString(v, "[FILTERED]")
-				if rule.Regex.MatchString(v2) {
+				if len(findMatches(v)) > 0 {
 					matchedData = append(matchedData, v)
 				}
 			}
 		}
 
-		if len(matchedData) >= a.matchConfig.MinCount {
+		if rule.ColumnValidator != nil && len(matchedData) > 0 {
+			values := []string{}
+			for _, v := range matchedData {
+				values = append(values, findMatches(v)...)
+			}
+			if !rule.ColumnValidator(values) {
+				matchedData = []string{}
+			}
+		}
+
+		// keyword lines count toward the minimum, so they are added below
+		if len(matchedData) >= a.matchConfig.MinCount || (len(matchedData) > 0 && hasKeywordRule(a.matchConfig, rule.Name)) {
+			ratio := matchRatio(len(matchedData), count)
 			confidence := rule.Confidence
-			// variable confidence
-			if confidence == "" {
-				if float64(len(matchedData))/float64(count) > 0.5 {
-					confidence = "high"
-				} else {
-					confidence = "low"
+			if confidence == "" && rule.ValueConfidence != nil {
+				confidence = "low"
+				for _, v := range matchedData {
+					for _, match := range findMatches(v) {
+						valueConfidence := rule.ValueConfidence(match)
+						if valueConfidence == "high" {
+							confidence = "high"
+						} else if valueConfidence == "" && confidence == "low" {
+							// fall back to variable confidence
+							confidence = ""
+						}
+					}
 				}
 			}
+			var score float64
+			if confidence == "" && rule.StrictValidator {
+				score = validatedScore(ratio)
+			} else if confidence == "" {
+				// variable confidence
+				score = ratioScore(ratio)
+			} else {
+				score = fixedScore(confidence, ratio)
+			}
+			if rule.MaxConfidence != "" {
+				score = math.Min(score, fixedScore(rule.MaxConfidence, ratio))
+			}
+			score *= validatorFactor(passRate) * uniquenessFactor(matchedData)
 
 			lineCount := len(matchedData)
+			locations := matchLocations(matchedValues[i], allowlists, findMatches)
+
+			var countries []string
+			if rule.Country != nil {
+				for _, v := range matchedData {
+					for _, match := range findMatches(v) {
+						if country := rule.Country(match); country != "" {
+							countries = append(countries, country)
+						}
+					}
+				}
+				countries = unique(countries)
+				sort.Strings(countries)
+			}
 
 			if onlyValues {
 				var matchedValues []string
 				for _, v := range matchedData {
-					v3 := rule.Regex.FindAllString(v, -1)
-					matchedValues = append(matchedValues, v3...)
+					matchedValues = append(matchedValues, findMatches(v)...)
 				}
 				matchedData = matchedValues
 			}
 
-			matchList = append(matchList, ruleMatch{RuleName: rule.Name, DisplayName: rule.DisplayName, Confidence: confidence, Identifier: colIdentifier, MatchedData: matchedData, LineCount: lineCount, MatchType: "value"})
+			matchList = append(matchList, ruleMatch{RuleName: rule.Name, DisplayName: rule.DisplayName, Score: score, Identifier: colIdentifier, MatchedData: matchedData, LineCount: lineCount, MatchType: "value", Category: rule.Category, Severity: rule.Severity, Tags: rule.Tags, Countries: countries, Location: firstLocation(locations), Locations: locations})
 		}
 	}
 
@@ -149,34 +308,229 @@ func (a *MatchFinder) CheckMatches(colIdentifier string, onlyValues bool) []rule
 		for _, v := range a.TokenValues[i] {
 			matchedData = append(matchedData, v.Line)
 		}
+		allowlists := a.ruleAllowlists(rule.Name)
+		findMatches := func(v string) []string {
+			return filterAllowlisted(allowlists, v, rule.findMatches)
+		}
+		matchedData = a.removeAllowlistedLines(rule.Name, matchedData, rule.findMatches)
 
 		if len(matchedData) >= a.matchConfig.MinCount {
-			confidence := "low"
-			if float64(len(matchedData))/float64(count) > 0.1 && len(unique(matchedData)) >= 10 {
-				confidence = "high"
+			// names are common words, so need more values to be confident
+			ratio := matchRatio(len(matchedData), count)
+			score := 0.35 * ratio
+			if ratio > 0.1 && len(unique(matchedData)) >= 10 {
+				score = 0.7 + 0.3*ratio
+			} else if rule.PairedRule != "" {
+				pairedData := a.pairedTokenValues(rule, matchedData)
+				pairedRatio := matchRatio(len(pairedData), count)
+				if pairedRatio > 0.1 {
+					if len(unique(pairedData)) >= 3 {
+						score = 0.7 + 0.3*pairedRatio
+					} else {
+						score = 0.4 + 0.25*pairedRatio
+					}
+				}
+			}
+
+			lineCount := len(matchedData)
+			locations := matchLocations(a.TokenValues[i], allowlists, findMatches)
+
+			if onlyValues {
+				var matchedValues []string
+				for _, v := range matchedData {
+					// TODO check all tokens
+					matchedValues = append(matchedValues, findMatches(v)...)
+				}
+				matchedData = matchedValues
 			}
 
+			matchList = append(matchList, ruleMatch{RuleName: rule.Name, DisplayName: rule.DisplayName, Score: score, Identifier: colIdentifier, MatchedData: matchedData, LineCount: lineCount, MatchType: "value", Category: rule.Category, Severity: rule.Severity, Tags: rule.Tags, Location: firstLocation(locations), Locations: locations})
+		}
+	}
+
+	for i, rule := range a.matchConfig.EntropyRules {
+		matchedData := []string{}
+		for _, v := range a.EntropyValues[i] {
+			matchedData = append(matchedData, v.Line)
+		}
+		allowlists := a.ruleAllowlists(rule.Name)
+		entropyMatches := func(v string) []string {
+			return a.findEntropyMatches(rule, v)
+		}
+		findMatches := func(v string) []string {
+			return filterAllowlisted(allowlists, v, entropyMatches)
+		}
+		matchedData = a.removeAllowlistedLines(rule.Name, matchedData, entropyMatches)
+
+		if len(matchedData) >= a.matchConfig.MinCount {
+			score := ratioScore(matchRatio(len(matchedData), count)) * uniquenessFactor(matchedData)
+
 			lineCount := len(matchedData)
+			locations := matchLocations(a.EntropyValues[i], allowlists, findMatches)
 
 			if onlyValues {
 				var matchedValues []string
 				for _, v := range matchedData {
-					tokens := tokenizer.Split(strings.ToLower(v), -1)
-					for _, token := range tokens {
-						// TODO check all tokens
-						if rule.Tokens.Contains(token) {
-							matchedValues = append(matchedValues, token)
+					matchedValues = append(matchedValues, findMatches(v)...)
+				}
+				matchedData = matchedValues
+			}
+
+			matchList = append(matchList, ruleMatch{RuleName: rule.Name, DisplayName: rule.DisplayName, Score: score, Identifier: colIdentifier, MatchedData: matchedData, LineCount: lineCount, MatchType: "value", Category: rule.Category, Severity: rule.Severity, Tags: rule.Tags, Location: firstLocation(locations), Locations: locations})
+		}
+	}
+
+	for i, rule := range a.matchConfig.KeywordRules {
+		allowlists := a.ruleAllowlists(rule.Name)
+		findMatches := func(v string) []keywordMatch {
+			matches := []keywordMatch{}
+			for _, match := range rule.findMatches(maskValue(allowlists, v)) {
+				if !allowlisted(allowlists, match.Value) {
+					matches = append(matches, match)
+				}
+			}
+			return matches
+		}
+
+		matchedData := []string{}
+		matchedValues := []string{}
+		keywords := []string{}
+		for _, v := range a.KeywordValues[i] {
+			matches := findMatches(v.Line)
+			if len(matches) > 0 {
+				matchedData = append(matchedData, v.Line)
+				for _, match := range matches {
+					matchedValues = append(matchedValues, match.Value)
+					keywords = append(keywords, match.Keyword)
+				}
+			}
+		}
+		keywords = unique(keywords)
+		sort.Strings(keywords)
+		locations := matchLocations(a.KeywordValues[i], allowlists, func(v string) []string {
+			values := []string{}
+			for _, match := range findMatches(v) {
+				values = append(values, match.Value)
+			}
+			return values
+		})
+
+		// add to the match from the regex rule with the same name
+		var regexRule regexRule
+		existing := -1
+		for _, r := range a.matchConfig.RegexRules {
+			if r.Name == rule.Name {
+				regexRule = r
+				for j, match := range matchList {
+					if match.RuleName == rule.Name {
+						existing = j
+					}
+				}
+			}
+		}
+
+		if existing != -1 {
+			if len(matchedData) == 0 {
+				continue
+			}
+
+			// lines the regex rule did not match
+			for _, v := range matchedData {
+				if len(filterAllowlisted(allowlists, v, regexRule.findValidMatches)) == 0 {
+					match := &matchList[existing]
+					match.LineCount++
+					if onlyValues {
+						for _, m := range findMatches(v) {
+							match.MatchedData = append(match.MatchedData, m.Value)
 						}
+					} else {
+						match.MatchedData = append(match.MatchedData, v)
 					}
 				}
+			}
+			score := fixedScore("high", matchRatio(matchList[existing].LineCount, count))
+			if score > matchList[existing].Score {
+				matchList[existing].Score = score
+			}
+			matchList[existing].Keywords = keywords
+			if locations != nil {
+				matchList[existing].Locations = mergeLocations(matchList[existing].Locations, locations)
+				matchList[existing].Location = firstLocation(matchList[existing].Locations)
+			}
+		} else if len(matchedData) >= a.matchConfig.MinCount {
+			lineCount := len(matchedData)
+
+			if onlyValues {
 				matchedData = matchedValues
 			}
 
-			matchList = append(matchList, ruleMatch{RuleName: rule.Name, DisplayName: rule.DisplayName, Confidence: confidence, Identifier: colIdentifier, MatchedData: matchedData, LineCount: lineCount, MatchType: "value"})
+			score := fixedScore("high", matchRatio(lineCount, count))
+			matchList = append(matchList, ruleMatch{RuleName: rule.Name, DisplayName: rule.DisplayName, Score: score, Identifier: colIdentifier, MatchedData: matchedData, LineCount: lineCount, MatchType: "value", Keywords: keywords, Category: rule.Category, Severity: rule.Severity, Tags: rule.Tags, Location: firstLocation(locations), Locations: locations})
 		}
 	}
 
-	return matchList
+	// regex matches that stayed below the minimum with keyword lines
+	newMatchList := []ruleMatch{}
+	for _, match := range matchList {
+		if match.LineCount >= a.matchConfig.MinCount {
+			newMatchList = append(newMatchList, match)
+		}
+	}
+	return newMatchList
+}
+
+// location of each match, for lines from files
+func matchLocations(lines []MatchLine, allowlists []allowlist, findMatches func(string) []string) []matchLocation {
+	locations := []matchLocation{}
+	for _, line := range lines {
+		if line.Offset < 0 {
+			return nil
+		}
+
+		// masking preserves offsets, so allowlisted parts are skipped
+		masked := maskValue(allowlists, line.Line)
+		start := 0
+		for _, match := range findMatches(line.Line) {
+			column := start
+			if i := strings.Index(masked[start:], match); i != -1 {
+				column += i
+				start = column + len(match)
+			} else if i := tokenIndex(masked[start:], match); i != -1 {
+				// tokens are lowercase and without accents
+				column += i
+				start = column + 1
+			}
+			locations = append(locations, matchLocation{Value: match, Line: line.LineIndex + 1, Column: column + 1, Offset: line.Offset + column})
+		}
+	}
+	if len(locations) == 0 {
+		return nil
+	}
+	return locations
+}
+
+// adds locations that are not already present, in file order
+func mergeLocations(locations []matchLocation, other []matchLocation) []matchLocation {
+	offsets := make(map[int]bool)
+	for _, location := range locations {
+		offsets[location.Offset] = true
+	}
+	for _, location := range other {
+		if !offsets[location.Offset] {
+			locations = append(locations, location)
+		}
+	}
+	sort.SliceStable(locations, func(i, j int) bool {
+		return locations[i].Offset < locations[j].Offset
+	})
+	return locations
+}
+
+func firstLocation(locations []matchLocation) *matchLocation {
+	if len(locations) == 0 {
+		return nil
+	}
+	return &locations[0]
 }
 
 func (a *MatchFinder) CheckTableData(table table, tableData *tableData) []ruleMatch {
@@ -189,49 +543,210 @@ func (a *MatchFinder) CheckTableData(table table, tableData *tableData) []ruleMa
 		// check values
 		values := columnValues[i]
 
-		var colIdentifier string
-		if table.displayName() == "" {
-			colIdentifier = col
-		} else {
-			colIdentifier = table.displayName() + "." + col
-		}
+		colIdentifier := columnIdentifier(table, col)
 
 		a.Clear()
 		a.ScanValues(values)
-		matchList := a.CheckMatches(colIdentifier, false)
+		matchList := a.checkValues(colIdentifier, false)
+
+		// combine with name evidence
+		rule := matchNameRule(columnNameParts(col), nearbyColumnParts(columnNames, i), a.matchConfig.NameRules)
+		if rule.Name != "" {
+			nameScore := rule.valueScore(values)
+			fused := false
+			for j, match := range matchList {
+				if match.RuleName == rule.Name {
+					matchList[j].Score = combineScores(match.Score, nameScore)
+					fused = true
+				}
+			}
+			if !fused && nameScore > 0 {
+				matchList = append(matchList, ruleMatch{RuleName: rule.Name, DisplayName: rule.DisplayName, Score: nameScore, Identifier: colIdentifier, MatchedData: values, MatchType: "name", Category: rule.Category, Severity: rule.Severity, Tags: rule.Tags})
+			}
+		}
+
+		tableMatchList = append(tableMatchList, matchList...)
+	}
 
-		// only check name if no matches
-		if len(matchList) == 0 {
-			name := strings.Replace(strings.ToLower(col), "_", "", -1)
+	tableMatchList = append(tableMatchList, a.checkMultiNameRules(table, tableData)...)
 
-			// check last part for nested data
-			parts := strings.Split(name, ".")
-			name = parts[len(parts)-1]
+	return a.finishMatches(tableMatchList)
+}
+
+func columnIdentifier(table table, col string) string {
+	if table.displayName() == "" {
+		return col
+	}
+	return table.displayName() + "." + col
+}
+
+// columns are grouped by their parent path for nested data (like address.city),
+// so each nested object is checked on its own
+func (a *MatchFinder) checkMultiNameRules(table table, tableData *tableData) []ruleMatch {
+	matchList := []ruleMatch{}
 
-			rule := matchNameRule(name, a.matchConfig.NameRules)
-			if rule.Name != "" {
-				matchList = append(matchList, ruleMatch{RuleName: rule.Name, DisplayName: rule.DisplayName, Confidence: "medium", Identifier: colIdentifier, MatchedData: values, MatchType: "name"})
+	parents := []string{}
+	parentColumns := make(map[string][]int)
+	for i, col := range tableData.ColumnNames {
+		parent := columnParent(col)
+		if _, ok := parentColumns[parent]; !ok {
+			parents = append(parents, parent)
+		}
+		parentColumns[parent] = append(parentColumns[parent], i)
+	}
+
+	for _, rule := range a.matchConfig.MultiNameRules {
+		for _, parent := range parents {
+			indexes := matchColumnGroups(rule, tableData.ColumnNames, parentColumns[parent])
+			if indexes == nil {
+				continue
+			}
+
+			cols := make([]string, len(indexes))
+			values := make([][]string, len(indexes))
+			var rowIndexes [][]int
+			if tableData.RowIndexes != nil {
+				rowIndexes = make([][]int, len(indexes))
+			}
+			for i, index := range indexes {
+				cols[i] = tableData.ColumnNames[index]
+				values[i] = tableData.ColumnValues[index]
+				if rowIndexes != nil {
+					rowIndexes[i] = tableData.RowIndexes[index]
+				}
+			}
+
+			rows := valueRows(values, rowIndexes)
+			validRows := rows
+			passRate := 1.0
+			if rule.Validator != nil {
+				validRows, passRate = filterRows(rows, rule.Validator)
+				// most rows failing means the columns are something else
+				if passRate < 0.5 {
+					continue
+				}
+			}
+
+			score := fixedScore("medium", 0)
+			var precision string
+			if rule.Precision != nil {
+				precision = rule.Precision(validRows)
+				if precision != "" {
+					score = precisionScore(precision)
+				}
+			}
+			// a few bad rows (like N/A) lower the score
+			score *= validatorFactor(passRate)
+
+			matchedData := make([]string, len(rows))
+			for i, row := range rows {
+				matchedData[i] = "(" + strings.Join(row, ", ") + ")"
 			}
+
+			identifier := columnIdentifier(table, strings.Join(cols, "+"))
+			matchList = append(matchList, ruleMatch{RuleName: rule.Name, DisplayName: rule.DisplayName, Score: score, Identifier: identifier, MatchedData: matchedData, MatchType: "name", Precision: precision, Category: rule.Category, Severity: rule.Severity, Tags: rule.Tags})
 		}
+	}
 
-		tableMatchList = append(tableMatchList, matchList...)
+	return matchList
+}
+
+// returns a column for each group, or nil if any group is missing
+func matchColumnGroups(rule multiNameRule, columnNames []string, candidates []int) []int {
+	indexes := make([]int, len(rule.ColumnNames))
+	used := make(map[int]bool)
+	for i, group := range rule.ColumnNames {
+		found := false
+		for _, index := range candidates {
+			if used[index] {
+				continue
+			}
+			if stringInSlice(strings.Join(columnNameParts(columnNames[index]), ""), group) {
+				indexes[i] = index
+				used[index] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
 	}
+	return indexes
+}
 
-	for _, rule := range a.matchConfig.MultiNameRules {
-		var latCol string
-		var lonCol string
-		for _, col := range columnNames {
-			if stringInSlice(col, rule.ColumnNames[0]) {
-				latCol = col
-			} else if stringInSlice(col, rule.ColumnNames[1]) {
-				lonCol = col
+// combines values in the same row, skipping rows without any values
+// documents can be missing fields, so their values are combined by row index
+// (using the first value when a document has more than one)
+func valueRows(values [][]string, rowIndexes [][]int) [][]string {
+	var tuples [][]string
+	if rowIndexes == nil {
+		rowCount := len(values[0])
+		for _, v := range values[1:] {
+			if len(v) < rowCount {
+				rowCount = len(v)
+			}
+		}
+
+		tuples = make([][]string, rowCount)
+		for row := range tuples {
+			tuples[row] = make([]string, len(values))
+			for i, v := range values {
+				tuples[row][i] = v[row]
+			}
+		}
+	} else {
+		rowCount := 0
+		for _, indexes := range rowIndexes {
+			for _, row := range indexes {
+				if row+1 > rowCount {
+					rowCount = row + 1
+				}
 			}
 		}
-		if latCol != "" && lonCol != "" {
-			// TODO show data
-			tableMatchList = append(tableMatchList, ruleMatch{RuleName: rule.Name, DisplayName: rule.DisplayName, Confidence: "medium", Identifier: table.displayName() + "." + latCol + "+" + lonCol, MatchType: "name"})
+
+		tuples = make([][]string, rowCount)
+		for row := range tuples {
+			tuples[row] = make([]string, len(values))
+		}
+		seen := make([]map[int]bool, len(values))
+		for i, v := range values {
+			seen[i] = make(map[int]bool)
+			for j, value := range v {
+				row := rowIndexes[i][j]
+				if !seen[i][row] {
+					tuples[row][i] = value
+					seen[i][row] = true
+				}
+			}
 		}
 	}
 
-	return tableMatchList
+	rows := [][]string{}
+	for _, tuple := range tuples {
+		empty := true
+		for _, v := range tuple {
+			if v != "" {
+				empty = false
+			}
+		}
+		if !empty {
+			rows = append(rows, tuple)
+		}
+	}
+	return rows
+}
+
+// rows passing the validator and their share of all rows
+func filterRows(rows [][]string, validator func([]string) bool) ([][]string, float64) {
+	validRows := [][]string{}
+	for _, row := range rows {
+		if validator(row) {
+			validRows = append(validRows, row)
+		}
+	}
+	if len(rows) == 0 {
+		return validRows, 1
+	}
+	return validRows, float64(len(validRows)) / float64(len(rows))
 }
@@ -95,15 +95,16 @@ func (a MongodbAdapter) FetchTableData(table table, limit int) (*tableData, erro
 	keyMap := make(map[string]int)
 
 	columnValues := make([][]string, 0)
+	rowIndexes := make([][]int, 0)
 
-	for cur.Next(ctx) {
+	for row := 0; cur.Next(ctx); row++ {
 		var result bson.D
 		err := cur.Decode(&result)
 		if err != nil {
 			return nil, err
 		}
 
-		keyMap, columnValues = scanObject(result, "", keyMap, columnValues)
+		keyMap, columnValues, rowIndexes = scanObject(result, "", row, keyMap, columnValues, rowIndexes)
 	}
 	if err := cur.Err(); err != nil {
 		return nil, err
@@ -114,10 +115,10 @@ func (a MongodbAdapter) FetchTableData(table table, limit int) (*tableData, erro
 		columnNames[i] = key
 	}
 
-	return &tableData{columnNames, columnValues}, nil
+	return &tableData{columnNames, columnValues, rowIndexes}, nil
 }
 
-func scanObject(object bson.D, prefix string, keyMap map[string]int, columnValues [][]string) (map[string]int, [][]string) {
+func scanObject(object bson.D, prefix string, row int, keyMap map[string]int, columnValues [][]string, rowIndexes [][]int) (map[string]int, [][]string, [][]int) {
 	for _, elem := range object {
 		key := fmt.Sprintf("%s%s", prefix, elem.Key)
 		i, ok := keyMap[key]
@@ -125,16 +126,18 @@ func scanObject(object bson.D, prefix string, keyMap map[string]int, columnValue
 			i = len(keyMap)
 			keyMap[key] = i
 			columnValues = append(columnValues, []string{})
+			rowIndexes = append(rowIndexes, []int{})
 		}
 
 		// TODO improve code
 		str, ok := elem.Value.(string)
 		if ok {
 			columnValues[i] = append(columnValues[i], str)
+			rowIndexes[i] = append(rowIndexes[i], row)
 		} else {
 			value, ok := elem.Value.(bson.D)
 			if ok {
-				keyMap, columnValues = scanObject(value, fmt.Sprintf("%s.", key), keyMap, columnValues)
+				keyMap, columnValues, rowIndexes = scanObject(value, fmt.Sprintf("%s.", key), row, keyMap, columnValues, rowIndexes)
 			} else {
 				arr, ok := elem.Value.(bson.A)
 				if ok {
@@ -148,10 +151,11 @@ func scanObject(object bson.D, prefix string, keyMap map[string]int, columnValue
 					// add as single value for now for correct document count
 					if len(values) > 0 {
 						columnValues[i] = append(columnValues[i], strings.Join(values, ", "))
+						rowIndexes[i] = append(rowIndexes[i], row)
 					}
 				}
 			}
 		}
 	}
-	return keyMap, columnValues
+	return keyMap, columnValues, rowIndexes
 }
@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type phoneRegion struct {
+	Region         string
+	CallingCode    string
+	TrunkPrefix    string
+	MinLength      int
+	MaxLength      int
+	NationalFormat *regexp.Regexp
+}
+
+var phoneRegions = loadPhoneRegions("phone_countries.txt")
+
+// international numbers (with a calling code) and national formats from the metadata
+var phoneRegex = makePhoneRegex(phoneRegions)
+
+func loadPhoneRegions(filename string) []phoneRegion {
+	data, err := dataFiles.ReadFile("data/" + filename)
+	if err != nil {
+		panic(err)
+	}
+
+	regions := []phoneRegion{}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) != 5 {
+			panic("Invalid phone metadata: " + line)
+		}
+
+		region := phoneRegion{Region: fields[0], CallingCode: fields[1]}
+		if fields[2] != "-" {
+			region.TrunkPrefix = fields[2]
+		}
+		lengths := strings.SplitN(fields[3], "-", 2)
+		region.MinLength, _ = strconv.Atoi(lengths[0])
+		region.MaxLength = region.MinLength
+		if len(lengths) == 2 {
+			region.MaxLength, _ = strconv.Atoi(lengths[1])
+		}
+		if fields[4] != "-" {
+			region.NationalFormat = regexp.MustCompile(`^(?:` + fields[4] + `)$`)
+		}
+		regions = append(regions, region)
+	}
+	return regions
+}
+
+func makePhoneRegex(regions []phoneRegion) *regexp.Regexp {
+	formats := []string{}
+	for _, region := range regions {
+		if region.NationalFormat != nil {
+			format := region.NationalFormat.String()
+			formats = append(formats, format[1:len(format)-1])
+		}
+	}
+	// 7 digit min and 15 digit max for international numbers
+	// https://stackoverflow.com/questions/14894899/what-is-the-minimum-length-of-a-valid-international-phone-number
+	regex := regexp.MustCompile(`(?:\+|%2B)[1-9](?:[\s.()-]{0,2}\d){6,14}\b|(?:\(|\b)(?:` + strings.Join(formats, "|") + `)\b`)
+	// formats can match part of a longer number from another region
+	regex.Longest()
+	return regex
+}
+
+func (region phoneRegion) validLength(nationalNumber string) bool {
+	return len(nationalNumber) >= region.MinLength && len(nationalNumber) <= region.MaxLength
+}
+
+// returns the region of a phone number and whether it's valid
+// international numbers with a calling code not in the metadata
+// are valid without a region
+func parsePhone(v string) (string, bool) {
+	v = strings.Replace(strings.TrimSpace(v), "%2B", "+", 1)
+
+	if strings.HasPrefix(v, "+") {
+		region, valid := parseInternationalPhone(v)
+		if valid {
+			return region, true
+		}
+	}
+
+	// national formats can start with a calling code (like +12 555 555 5555)
+	nationalDigits := digitsOnly(phoneCallingCode.ReplaceAllString(v, ""))
+	for _, region := range phoneRegions {
+		if region.NationalFormat != nil && region.NationalFormat.MatchString(v) {
+			nationalNumber := strings.TrimPrefix(nationalDigits, region.TrunkPrefix)
+			if region.validLength(nationalNumber) {
+				return region.Region, true
+			}
+		}
+	}
+	return "", false
+}
+
+var phoneCallingCode = regexp.MustCompile(`^\+\d{1,2}\s`)
+
+func parseInternationalPhone(v string) (string, bool) {
+	// the trunk prefix is sometimes included, like +44 (0)20
+	digits := digitsOnly(strings.Replace(v, "(0)", "", 1))
+	for i := 1; i <= 3 && i < len(digits); i++ {
+		callingCode := digits[:i]
+		found := false
+		for _, region := range phoneRegions {
+			if region.CallingCode == callingCode {
+				found = true
+				if region.validLength(digits[i:]) {
+					return region.Region, true
+				}
+			}
+		}
+		// calling codes are prefix-free
+		if found {
+			return "", false
+		}
+	}
+	return "", len(digits) >= 7 && len(digits) <= 15
+}
+
+func validPhone(v string) bool {
+	_, valid := parsePhone(v)
+	return valid
+}
+
+func phoneCountry(v string) string {
+	region, _ := parsePhone(v)
+	return region
+}
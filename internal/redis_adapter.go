@@ -123,5 +123,5 @@ func (a RedisAdapter) FetchTableData(table table, limit int) (*tableData, error)
 		columnNames[i] = key
 	}
 
-	return &tableData{columnNames, columnValues}, nil
+	return &tableData{columnNames, columnValues, nil}, nil
 }
@@ -1,21 +1,44 @@
 package internal
 
 import (
+	"embed"
 	"regexp"
+	"strings"
 
 	mapset "github.com/deckarep/golang-set"
 )
 
+// column names are matched as whole tokens unless MatchMode is
+// exact, prefix, suffix, or contains
+// columns containing any of Exclude are skipped
+// Validator checks sampled values are consistent with the name
+// RequiredColumns must match another column in the same object
+// for names that are only sensitive in context (like account numbers)
 type nameRule struct {
-	Name        string
-	DisplayName string
-	ColumnNames []string
+	Name            string
+	DisplayName     string
+	ColumnNames     []string
+	Category        string
+	Severity        string
+	Tags            []string
+	MatchMode       string
+	Exclude         []string
+	Validator       func(string) bool
+	RequiredColumns []string
 }
 
+// matches tables with a column from each group
+// Validator checks each row of values (in group order) and Precision
+// sets confidence from the values when present
 type multiNameRule struct {
 	Name        string
 	DisplayName string
 	ColumnNames [][]string
+	Category    string
+	Severity    string
+	Tags        []string
+	Validator   func([]string) bool
+	Precision   func([][]string) string
 }
 
 type regexRule struct {
@@ -23,53 +46,302 @@ type regexRule struct {
 	DisplayName string
 	Confidence  string
 	Regex       *regexp.Regexp
+	Validator   func(string) bool
+	// the validator is strict (like a checksum), so values
+	// that pass it can lift variable confidence to high
+	StrictValidator bool
+	// checks all matched values together, for rules
+	// where individual values are ambiguous (like dates)
+	ColumnValidator func([]string) bool
+	Category        string
+	Severity        string
+	Tags            []string
+	// highest confidence from values alone, for rules that need
+	// a column name or keyword to be confident (like dates)
+	MaxConfidence string
+	// per-value confidence for rules without a fixed confidence
+	// the highest confidence of any matched value is used
+	ValueConfidence func(string) string
+	// country of each matched value (like from a phone number's calling code)
+	Country func(string) string
 }
 
 type tokenRule struct {
 	Name        string
 	DisplayName string
 	Tokens      mapset.Set
+	Category    string
+	Severity    string
+	Tags        []string
+	// values with tokens from both rules (like full names)
+	// are stronger evidence than either rule alone
+	PairedRule string
+}
+
+// values that are only sensitive near a keyword (like "SSN: 123456789")
+type keywordRule struct {
+	Name        string
+	DisplayName string
+	Keywords    *regexp.Regexp
+	Regex       *regexp.Regexp
+	Validator   func(string) bool
+	Category    string
+	Severity    string
+	Tags        []string
+	// max characters between the keyword and value
+	Distance int
 }
 
-// columns are lowercased and _ are removed
+// flags long base64 and base64url substrings with high Shannon entropy
+// hex strings and UUIDs are skipped since they are usually identifiers
+type entropyRule struct {
+	Name        string
+	DisplayName string
+	MinLength   int
+	MinEntropy  float64
+	Category    string
+	Severity    string
+	Tags        []string
+}
+
+// bank data is personal data covered by financial privacy laws rather than PCI DSS
+var bankingTags = []string{"GLBA"}
+
+// columns are split into tokens, lowercased, and joined without separators
 // this allows use a single list for under_score and camelCase
+// and for names inside longer columns (like customer_phone and billing_zip)
 // no rules for email or IP, since they can be detected automatically
-// keep last name and phone until better international support
+// keep last name until better international support
 var nameRules = []nameRule{
-	nameRule{Name: "surname", DisplayName: "last names", ColumnNames: []string{"lastname", "lname", "surname"}},
-	nameRule{Name: "phone", DisplayName: "phone numbers", ColumnNames: []string{"phone", "phonenumber"}},
+	nameRule{Name: "surname", DisplayName: "last names", ColumnNames: []string{"lastname", "lname", "surname"}, Validator: hasLetter},
+	nameRule{Name: "phone", DisplayName: "phone numbers", ColumnNames: []string{"phone", "phonenumber"}, Exclude: []string{"phonetype", "phoneverified", "phoneconfirmed"}, Validator: validPhoneDigits},
 	nameRule{Name: "date_of_birth", DisplayName: "dates of birth", ColumnNames: []string{"dateofbirth", "birthday", "dob"}},
-	nameRule{Name: "postal_code", DisplayName: "postal codes", ColumnNames: []string{"zip", "zipcode", "postalcode"}},
-	nameRule{Name: "oauth_token", DisplayName: "OAuth tokens", ColumnNames: []string{"accesstoken", "refreshtoken"}},
+	nameRule{Name: "postal_code", DisplayName: "postal codes", Severity: "low", ColumnNames: []string{"zip", "zipcode", "postalcode"}, Exclude: []string{"zipfile"}, Validator: hasDigit},
+	nameRule{Name: "routing_number", DisplayName: "routing numbers", Severity: "low", Tags: bankingTags, ColumnNames: []string{"routingnumber", "routingno", "aba", "abanumber", "abaroutingnumber", "routingtransitnumber", "rtn"}, Validator: validABA},
+	nameRule{Name: "swift_code", DisplayName: "SWIFT/BIC codes", Severity: "low", Tags: bankingTags, ColumnNames: []string{"swift", "swiftcode", "swiftbic", "bic", "biccode"}, Validator: validBIC},
+	// account numbers are too generic (like customer account numbers) without a bank column
+	nameRule{Name: "financial_account", DisplayName: "bank account numbers", Severity: "high", Tags: bankingTags, ColumnNames: []string{"accountnumber", "accountno", "acctno", "acctnum", "acctnumber", "bankaccount", "bankaccountnumber", "iban"}, Validator: validAccountNumber, RequiredColumns: []string{"routing", "aba", "bank", "sortcode", "swift", "bic"}},
+	nameRule{Name: "us_drivers_license", DisplayName: "driver's license numbers", Severity: "high", ColumnNames: []string{"driverslicense", "driverslicensenumber", "driverlicense", "driverlicensenumber", "dlnumber", "dlno", "dln"}, Validator: validDriversLicense},
+	nameRule{Name: "passport", DisplayName: "passport numbers", Severity: "high", ColumnNames: []string{"passport", "passportnumber", "passportno", "passportid"}, Validator: validPassportNumber},
+	nameRule{Name: "vin", DisplayName: "VINs", ColumnNames: []string{"vin", "vinnumber", "vehicleidentificationnumber"}, Validator: validVIN},
+	nameRule{Name: "wallet_address", DisplayName: "cryptocurrency wallet addresses", ColumnNames: []string{"walletaddress", "cryptoaddress", "bitcoinaddress", "btcaddress", "ethereumaddress", "ethaddress"}, Validator: validWalletAddress},
+	nameRule{Name: "oauth_token", DisplayName: "OAuth tokens", Category: "secret", ColumnNames: []string{"accesstoken", "refreshtoken"}, Exclude: []string{"expires", "expiry", "expiration"}},
 }
 
 var multiNameRules = []multiNameRule{
-	multiNameRule{Name: "location", DisplayName: "location data", ColumnNames: [][]string{{"latitude", "lat"}, {"longitude", "lon", "lng"}}},
+	multiNameRule{Name: "location", DisplayName: "location data", Category: "location", ColumnNames: [][]string{{"latitude", "lat"}, {"longitude", "lon", "lng"}}, Validator: validCoordinatesRow, Precision: coordinatePrecision},
+	multiNameRule{Name: "full_address", DisplayName: "full addresses", ColumnNames: [][]string{{"street", "streetaddress", "address", "address1", "addressline1"}, {"city", "town"}, {"zip", "zipcode", "postalcode", "postcode"}}},
+	multiNameRule{Name: "full_name", DisplayName: "full names", ColumnNames: [][]string{{"firstname", "fname", "givenname"}, {"lastname", "lname", "surname", "familyname"}}},
 }
 
-// TODO IPv6
+// the first group takes all hex digits before it (and a colon run starts at
+// its first colon), so parts of longer runs fail validation instead of matching
+var ipv6Regex = regexp.MustCompile(`(?i)(?:\b[0-9a-f]*:|\B:)(?:[0-9a-f]{0,4}:){1,6}(?:\d{1,3}(?:\.\d{1,3}){3}|[0-9a-f]{0,4})(?:%[0-9a-z._-]+)?`)
+
+// ISO, US, and EU dates, with or without a time
+var dateRegex = regexp.MustCompile(`\b(?:\d{4}-\d{1,2}-\d{1,2}(?:[T ]\d{1,2}:\d{2}(?::\d{2}(?:\.\d+)?)?(?:Z|[+-]\d{2}:?\d{2})?)?|\d{1,2}[/.-]\d{1,2}[/.-]\d{4}(?:\s+\d{1,2}:\d{2}(?::\d{2})?)?)\b`)
+
 // TODO more popular access tokens
 var regexRules = []regexRule{
 	regexRule{Name: "email", DisplayName: "emails", Confidence: "high", Regex: regexp.MustCompile(`\b[\w][\w+.-]+(@|%40)[a-z\d-]+(\.[a-z\d-]+)*\.[a-z]+\b`)},
-	// TODO make high confidence
-	regexRule{Name: "ip", DisplayName: "IP addresses", Regex: regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`)},
-	regexRule{Name: "credit_card", DisplayName: "credit card numbers", Regex: regexp.MustCompile(`(\b[3456]\d{3}[\s+-]\d{4}[\s+-]\d{4}[\s+-]\d{4}\b)|(\b[3456]\d{15}\b)`)},
-	regexRule{Name: "phone", DisplayName: "phone numbers", Regex: regexp.MustCompile(`(\b(\+\d{1,2}\s)?\(?\d{3}\)?[\s+.-]\d{3}[\s+.-]\d{4}\b)|((?:\+|%2B)[1-9]\d{6,14}\b)`)},
-	regexRule{Name: "ssn", DisplayName: "SSNs", Regex: regexp.MustCompile(`\b\d{3}[\s+-]\d{2}[\s+-]\d{4}\b`)},
+	regexRule{Name: "ip", DisplayName: "IP addresses", Severity: "low", Regex: regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`), Validator: validIPv4, StrictValidator: true},
+	regexRule{Name: "ipv6", DisplayName: "IPv6 addresses", Severity: "low", Regex: ipv6Regex, Validator: validIPv6, ValueConfidence: ipv6Confidence},
+	regexRule{Name: "credit_card", DisplayName: "credit card numbers", Category: "pci", Regex: regexp.MustCompile(`(\b[3456]\d{3}[\s+-]\d{4}[\s+-]\d{4}[\s+-]\d{4}\b)|(\b[3456]\d{15}\b)`), Validator: validLuhn, StrictValidator: true},
+	regexRule{Name: "phone", DisplayName: "phone numbers", Regex: phoneRegex, Validator: validPhone, Country: phoneCountry},
+	regexRule{Name: "ssn", DisplayName: "SSNs", Severity: "high", Regex: regexp.MustCompile(`\b\d{3}[\s+-]\d{2}[\s+-]\d{4}\b`), Validator: validSSN, StrictValidator: true},
+	regexRule{Name: "date_of_birth", DisplayName: "dates of birth", Regex: dateRegex, Validator: validDate, ColumnValidator: plausibleBirthDates, MaxConfidence: "medium"},
 	regexRule{Name: "street", DisplayName: "street addresses", Regex: regexp.MustCompile(`(?i)\b\d+\b.{4,60}\b(st|street|ave|avenue|road|rd|drive|dr)\b`)},
-	regexRule{Name: "oauth_token", DisplayName: "OAuth tokens", Regex: regexp.MustCompile(`ya29\..{60,200}`)}, // google
-	// TODO make high confidence
-	regexRule{Name: "mac", DisplayName: "MAC addresses", Regex: regexp.MustCompile(`\b[0-9a-fA-F]{2}(?:(?::|%3A)[0-9a-fA-F]{2}){5}\b`)},
+	regexRule{Name: "oauth_token", DisplayName: "OAuth tokens", Category: "secret", Regex: regexp.MustCompile(`ya29\..{60,200}`)}, // google
+	regexRule{Name: "location", DisplayName: "location data", Category: "location", Regex: coordinatesRegex, Validator: validCoordinatesValue, ValueConfidence: coordinatesConfidence},
+	// second line of the machine readable zone (MRZ) from scanned passports
+	regexRule{Name: "passport", DisplayName: "passport numbers", Confidence: "high", Severity: "high", Regex: regexp.MustCompile(`\b[A-Z0-9<]{9}\d[A-Z<]{3}\d{7}[MFX<]\d{7}[A-Z0-9<]{14}[\d<]\d\b`), Validator: validPassportMRZ},
+	regexRule{Name: "vin", DisplayName: "VINs", Regex: regexp.MustCompile(`\b[A-HJ-NPR-Z0-9]{17}\b`), Validator: validVIN},
+	regexRule{Name: "wallet_address", DisplayName: "cryptocurrency wallet addresses", Regex: walletAddressRegex, Validator: validWalletAddress, ValueConfidence: walletAddressConfidence},
+	regexRule{Name: "mac", DisplayName: "MAC addresses", Severity: "low", Regex: regexp.MustCompile(`\b[0-9a-fA-F]{2}(?:(?::|%3A)[0-9a-fA-F]{2}){5}\b`), Validator: validMac, StrictValidator: true},
 	// // Custom Rules
-	regexRule{Name: "jwt", DisplayName: "JWT Tokens", Regex: regexp.MustCompile(`(access_token=)[a-zA-Z0-9_.-]+|(accessToken=)[a-zA-Z0-9_.-]+|("?bearerToken"?: *"?)[a-zA-Z0-9_.-]+("?)|(Authorization: +Bearer +)[a-zA-Z0-9_.-]+|(Bearer +)[a-zA-Z0-9_.-]+`)},
-	regexRule{Name: "imei", DisplayName: "IMEI Numbers", Regex: regexp.MustCompile(`('imei': *')[a-zA-Z0-9]+(')|\\*"imei\\*": *\\*"[a-zA-Z0-9]+\\*"`)},
-	regexRule{Name: "card_info", DisplayName: "Card Info", Regex: regexp.MustCompile(`'card(Number|Name|Ctype)': *'[a-zA-Z0-9 -]+'|\\*"card(Number|Name|Ctype)\\*": *\\*"[0-9a-zA-Z -]+\\*"`)},
+	regexRule{Name: "jwt", DisplayName: "JWT Tokens", Category: "secret", Regex: regexp.MustCompile(`(access_token=)[a-zA-Z0-9_.-]+|(accessToken=)[a-zA-Z0-9_.-]+|("?bearerToken"?: *"?)[a-zA-Z0-9_.-]+("?)|(Authorization: +Bearer +)[a-zA-Z0-9_.-]+|(Bearer +)[a-zA-Z0-9_.-]+`)},
+	regexRule{Name: "imei", DisplayName: "IMEI Numbers", Severity: "low", Regex: regexp.MustCompile(`('imei': *')[a-zA-Z0-9]+(')|\\*"imei\\*": *\\*"[a-zA-Z0-9]+\\*"`)},
+	regexRule{Name: "card_info", DisplayName: "Card Info", Category: "pci", Regex: regexp.MustCompile(`'card(Number|Name|Ctype)': *'[a-zA-Z0-9 -]+'|\\*"card(Number|Name|Ctype)\\*": *\\*"[0-9a-zA-Z -]+\\*"`)},
 	regexRule{Name: "phone_number_text", DisplayName: "Phone numbers in text", Regex: regexp.MustCompile(`phone number: (\d+)`)},
 }
 
+// looser patterns than the regex rules with the same name, since the keyword provides context
+// list longer keywords first since the first matching keyword is reported
+var keywordRules = []keywordRule{
+	keywordRule{Name: "ssn", DisplayName: "SSNs", Severity: "high", Keywords: keywordsRegex("ssn", "ss#", "social security number", "social security"), Regex: regexp.MustCompile(`\b\d{3}[\s+-]?\d{2}[\s+-]?\d{4}\b`), Validator: validSSN, Distance: 20},
+	keywordRule{Name: "date_of_birth", DisplayName: "dates of birth", Keywords: keywordsRegex("dob", "d.o.b", "date of birth", "birth date", "birthdate", "birthday", "born"), Regex: dateRegex, Validator: validBirthDate, Distance: 20},
+	keywordRule{Name: "us_drivers_license", DisplayName: "driver's license numbers", Keywords: keywordsRegex("driver's license number", "driver's license", "drivers license", "driver license", "dl number", "dl#", "dln"), Regex: regexp.MustCompile(`\b[A-Z]{0,3}\d[A-Z\d-]{2,20}[A-Z\d]\b`), Validator: validDriversLicense, Severity: "high", Distance: 20},
+	// US passports
+	keywordRule{Name: "passport", DisplayName: "passport numbers", Keywords: keywordsRegex("passport number", "passport no", "passport"), Regex: regexp.MustCompile(`\b(?:[A-Z]\d{8}|\d{9})\b`), Severity: "high", Distance: 20},
+	keywordRule{Name: "routing_number", DisplayName: "routing numbers", Keywords: keywordsRegex("routing number", "routing no", "routing", "aba", "rtn"), Regex: regexp.MustCompile(`\b\d{9}\b`), Validator: validABA, Severity: "low", Tags: bankingTags, Distance: 20},
+	keywordRule{Name: "swift_code", DisplayName: "SWIFT/BIC codes", Keywords: keywordsRegex("swift code", "swift", "bic"), Regex: regexp.MustCompile(`\b[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}(?:[A-Z0-9]{3})?\b`), Validator: validBIC, Severity: "low", Tags: bankingTags, Distance: 20},
+	keywordRule{Name: "phone", DisplayName: "phone numbers", Keywords: keywordsRegex("phone number", "phone", "telephone", "tel", "mobile", "cell"), Regex: regexp.MustCompile(`(?:\+|\b)\d[\d\s().-]{5,18}\d\b`), Validator: validPhoneDigits, Distance: 20},
+}
+
 // first 300 from 2010 US Census https://www.census.gov/topics/population/genealogy/data/2010_surnames.html
 // first 300 covered ~30% cumulative density inn 1990 US Census
 var lastNames = []interface{}{"smith", "johnson", "williams", "brown", "jones", "garcia", "miller", "davis", "rodriguez", "martinez", "hernandez", "lopez", "gonzalez", "wilson", "anderson", "thomas", "taylor", "moore", "jackson", "martin", "lee", "perez", "thompson", "white", "harris", "sanchez", "clark", "ramirez", "lewis", "robinson", "walker", "young", "allen", "king", "wright", "scott", "torres", "nguyen", "hill", "flores", "green", "adams", "nelson", "baker", "hall", "rivera", "campbell", "mitchell", "carter", "roberts", "gomez", "phillips", "evans", "turner", "diaz", "parker", "cruz", "edwards", "collins", "reyes", "stewart", "morris", "morales", "murphy", "cook", "rogers", "gutierrez", "ortiz", "morgan", "cooper", "peterson", "bailey", "reed", "kelly", "howard", "ramos", "kim", "cox", "ward", "richardson", "watson", "brooks", "chavez", "wood", "james", "bennett", "gray", "mendoza", "ruiz", "hughes", "price", "alvarez", "castillo", "sanders", "patel", "myers", "long", "ross", "foster", "jimenez", "powell", "jenkins", "perry", "russell", "sullivan", "bell", "coleman", "butler", "henderson", "barnes", "gonzales", "fisher", "vasquez", "simmons", "romero", "jordan", "patterson", "alexander", "hamilton", "graham", "reynolds", "griffin", "wallace", "moreno", "west", "cole", "hayes", "bryant", "herrera", "gibson", "ellis", "tran", "medina", "aguilar", "stevens", "murray", "ford", "castro", "marshall", "owens", "harrison", "fernandez", "mcdonald", "woods", "washington", "kennedy", "wells", "vargas", "henry", "chen", "freeman", "webb", "tucker", "guzman", "burns", "crawford", "olson", "simpson", "porter", "hunter", "gordon", "mendez", "silva", "shaw", "snyder", "mason", "dixon", "munoz", "hunt", "hicks", "holmes", "palmer", "wagner", "black", "robertson", "boyd", "rose", "stone", "salazar", "fox", "warren", "mills", "meyer", "rice", "schmidt", "garza", "daniels", "ferguson", "nichols", "stephens", "soto", "weaver", "ryan", "gardner", "payne", "grant", "dunn", "kelley", "spencer", "hawkins", "arnold", "pierce", "vazquez", "hansen", "peters", "santos", "hart", "bradley", "knight", "elliott", "cunningham", "duncan", "armstrong", "hudson", "carroll", "lane", "riley", "andrews", "alvarado", "ray", "delgado", "berry", "perkins", "hoffman", "johnston", "matthews", "pena", "richards", "contreras", "willis", "carpenter", "lawrence", "sandoval", "guerrero", "george", "chapman", "rios", "estrada", "ortega", "watkins", "greene", "nunez", "wheeler", "valdez", "harper", "burke", "larson", "santiago", "maldonado", "morrison", "franklin", "carlson", "austin", "dominguez", "carr", "lawson", "jacobs", "obrien", "lynch", "singh", "vega", "bishop", "montgomery", "oliver", "jensen", "harvey", "williamson", "gilbert", "dean", "sims", "espinoza", "howell", "li", "wong", "reid", "hanson", "le", "mccoy", "garrett", "burton", "fuller", "wang", "weber", "welch", "rojas", "lucas", "marquez", "fields", "park", "yang", "little", "banks", "padilla", "day", "walsh", "bowman", "schultz", "luna", "fowler", "mejia"}
+
+//go:embed data/*.txt
+var dataFiles embed.FS
+
+// one lowercase token per line, with accents removed
+func loadTokens(filename string) mapset.Set {
+	data, err := dataFiles.ReadFile("data/" + filename)
+	if err != nil {
+		panic(err)
+	}
+
+	tokens := mapset.NewSet()
+	for _, token := range strings.Fields(string(data)) {
+		tokens.Add(foldAccents(token))
+	}
+	return tokens
+}
+
+// most common given names from US Social Security card applications https://www.ssa.gov/oact/babynames/decades/century.html
+// excludes names that are also common words (like grace, may, and will)
 var tokenRules = []tokenRule{
-	tokenRule{Name: "surname", DisplayName: "last names", Tokens: mapset.NewSetFromSlice(lastNames)},
+	tokenRule{Name: "surname", DisplayName: "last names", Tokens: mapset.NewSetFromSlice(lastNames), PairedRule: "given_name"},
+	tokenRule{Name: "given_name", DisplayName: "first names", Tokens: loadTokens("given_names.txt"), PairedRule: "surname"},
+}
+
+// no entropy rules by default since random identifiers are common
+var entropyRules = []entropyRule{}
+
+// token rules with the same name as an existing rule add to its tokens
+type rulePack struct {
+	RegexRules   []regexRule
+	NameRules    []nameRule
+	TokenRules   []tokenRule
+	EntropyRules []entropyRule
+}
+
+var ibanRule = regexRule{Name: "iban", DisplayName: "IBANs", Confidence: "high", Regex: regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?\b`), Validator: validIBAN}
+var ibanNameRule = nameRule{Name: "iban", DisplayName: "IBANs", ColumnNames: []string{"iban", "ibannumber"}}
+
+// enabled with --rule-pack
+var rulePacks = map[string]rulePack{
+	"br": rulePack{
+		RegexRules: []regexRule{
+			regexRule{Name: "br_cpf", DisplayName: "Brazilian CPF numbers", Severity: "high", Confidence: "high", Regex: regexp.MustCompile(`\b\d{3}\.?\d{3}\.?\d{3}-?\d{2}\b`), Validator: validCPF},
+			ibanRule,
+		},
+		NameRules: []nameRule{
+			nameRule{Name: "br_cpf", DisplayName: "Brazilian CPF numbers", Severity: "high", ColumnNames: []string{"cpf", "cpfnumber"}},
+			ibanNameRule,
+		},
+		TokenRules: []tokenRule{
+			tokenRule{Name: "given_name", DisplayName: "first names", Tokens: loadTokens("given_names_br.txt"), PairedRule: "surname"},
+		},
+	},
+	"ca": rulePack{
+		RegexRules: []regexRule{
+			regexRule{Name: "ca_sin", DisplayName: "Canadian SINs", Severity: "high", Regex: regexp.MustCompile(`\b\d{3}[\s-]?\d{3}[\s-]?\d{3}\b`), Validator: validSIN},
+		},
+		NameRules: []nameRule{
+			nameRule{Name: "ca_sin", DisplayName: "Canadian SINs", Severity: "high", ColumnNames: []string{"sin", "sinnumber", "socialinsurancenumber"}},
+		},
+	},
+	"de": rulePack{
+		RegexRules: []regexRule{
+			regexRule{Name: "de_tax_id", DisplayName: "German tax IDs", Severity: "high", Regex: regexp.MustCompile(`\b[1-9]\d(?:\s?\d{3}){3}\b`), Validator: validGermanTaxID},
+			ibanRule,
+		},
+		NameRules: []nameRule{
+			nameRule{Name: "de_tax_id", DisplayName: "German tax IDs", Severity: "high", ColumnNames: []string{"steuerid", "steuernummer", "steueridentifikationsnummer", "idnr"}},
+			ibanNameRule,
+		},
+		TokenRules: []tokenRule{
+			tokenRule{Name: "given_name", DisplayName: "first names", Tokens: loadTokens("given_names_de.txt"), PairedRule: "surname"},
+		},
+	},
+	"es": rulePack{
+		RegexRules: []regexRule{
+			regexRule{Name: "es_dni", DisplayName: "Spanish DNI/NIE numbers", Severity: "high", Confidence: "high", Regex: regexp.MustCompile(`\b(?:\d{8}|[XYZ]-?\d{7})-?[A-Z]\b`), Validator: validDNI},
+			ibanRule,
+		},
+		NameRules: []nameRule{
+			nameRule{Name: "es_dni", DisplayName: "Spanish DNI/NIE numbers", Severity: "high", ColumnNames: []string{"dni", "nie", "nif"}},
+			ibanNameRule,
+		},
+		TokenRules: []tokenRule{
+			tokenRule{Name: "given_name", DisplayName: "first names", Tokens: loadTokens("given_names_es.txt"), PairedRule: "surname"},
+		},
+	},
+	"eu": rulePack{
+		RegexRules: []regexRule{ibanRule},
+		NameRules:  []nameRule{ibanNameRule},
+	},
+	"fr": rulePack{
+		RegexRules: []regexRule{
+			regexRule{Name: "fr_insee", DisplayName: "French INSEE numbers", Severity: "high", Confidence: "high", Regex: regexp.MustCompile(`\b[12]\s?\d{2}\s?\d{2}\s?(?:\d{2}|2[AB])\s?\d{3}\s?\d{3}\s?\d{2}\b`), Validator: validINSEE},
+			ibanRule,
+		},
+		NameRules: []nameRule{
+			nameRule{Name: "fr_insee", DisplayName: "French INSEE numbers", Severity: "high", ColumnNames: []string{"insee", "nir", "numerosecu", "numerosecuritesociale"}},
+			ibanNameRule,
+		},
+		TokenRules: []tokenRule{
+			tokenRule{Name: "given_name", DisplayName: "first names", Tokens: loadTokens("given_names_fr.txt"), PairedRule: "surname"},
+		},
+	},
+	"in": rulePack{
+		RegexRules: []regexRule{
+			regexRule{Name: "in_aadhaar", DisplayName: "Aadhaar numbers", Severity: "high", Regex: regexp.MustCompile(`\b[2-9]\d{3}[\s-]?\d{4}[\s-]?\d{4}\b`), Validator: validAadhaar},
+		},
+		NameRules: []nameRule{
+			nameRule{Name: "in_aadhaar", DisplayName: "Aadhaar numbers", Severity: "high", ColumnNames: []string{"aadhaar", "aadhar", "aadhaarnumber"}},
+		},
+		TokenRules: []tokenRule{
+			tokenRule{Name: "given_name", DisplayName: "first names", Tokens: loadTokens("given_names_in.txt"), PairedRule: "surname"},
+		},
+	},
+	"phi": rulePack{
+		RegexRules: []regexRule{
+			regexRule{Name: "npi", DisplayName: "NPI numbers", Category: "phi", Regex: regexp.MustCompile(`\b[12]\d{9}\b`), Validator: validNPI},
+			regexRule{Name: "dea_number", DisplayName: "DEA numbers", Confidence: "high", Category: "phi", Regex: regexp.MustCompile(`\b[ABCDEFGHJKLMPRSTUX][A-Z9]\d{7}\b`), Validator: validDEA},
+			regexRule{Name: "mbi", DisplayName: "Medicare Beneficiary Identifiers", Confidence: "high", Category: "phi", Regex: regexp.MustCompile(`\b[1-9][AC-HJKMNP-RT-Y][AC-HJKMNP-RT-Y\d]\d-?[AC-HJKMNP-RT-Y][AC-HJKMNP-RT-Y\d]\d-?[AC-HJKMNP-RT-Y]{2}\d{2}\b`)},
+			// codes without a decimal (like A15) are too ambiguous
+			regexRule{Name: "icd10", DisplayName: "ICD-10 diagnosis codes", Category: "phi", Regex: regexp.MustCompile(`\b[A-TV-Z]\d[\dA-Z]\.[\dA-Z]{1,4}\b`)},
+			// 4-4-2, 5-3-2, 5-4-1, and 5-4-2 (HIPAA 11 digit) formats
+			regexRule{Name: "ndc", DisplayName: "NDC drug codes", Category: "phi", Regex: regexp.MustCompile(`\b(?:\d{4}-\d{4}-\d{2}|\d{5}-\d{3}-\d{2}|\d{5}-\d{4}-\d{1,2})\b`)},
+		},
+		NameRules: []nameRule{
+			nameRule{Name: "mrn", DisplayName: "medical record numbers", Category: "phi", ColumnNames: []string{"mrn", "medicalrecordnumber", "medicalrecordno", "medicalrecordid"}},
+			nameRule{Name: "diagnosis", DisplayName: "diagnoses", Category: "phi", ColumnNames: []string{"diagnosis", "diagnoses", "diagnosiscode", "icd10", "icdcode"}},
+			nameRule{Name: "insurance_id", DisplayName: "insurance IDs", Category: "phi", ColumnNames: []string{"insuranceid", "insurancenumber", "insurancememberid", "healthplanid"}},
+			nameRule{Name: "npi", DisplayName: "NPI numbers", Category: "phi", ColumnNames: []string{"npi", "npinumber"}},
+			nameRule{Name: "mbi", DisplayName: "Medicare Beneficiary Identifiers", Category: "phi", ColumnNames: []string{"mbi", "medicarenumber", "medicareid"}},
+		},
+	},
+	"secrets": rulePack{
+		RegexRules: []regexRule{
+			regexRule{Name: "aws_access_key", DisplayName: "AWS access keys", Confidence: "high", Category: "secret", Regex: regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`)},
+			regexRule{Name: "aws_secret_key", DisplayName: "AWS secret keys", Confidence: "high", Category: "secret", Severity: "critical", Regex: regexp.MustCompile(`(?i)aws.{0,20}secret.{0,20}[=:"'\s]\s*["']?[A-Za-z0-9/+]{40}\b`)},
+			regexRule{Name: "github_token", DisplayName: "GitHub tokens", Confidence: "high", Category: "secret", Regex: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36}|github_pat_[A-Za-z0-9_]{82})\b`)},
+			regexRule{Name: "gitlab_token", DisplayName: "GitLab tokens", Confidence: "high", Category: "secret", Regex: regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20}\b`)},
+			regexRule{Name: "slack_token", DisplayName: "Slack tokens", Confidence: "high", Category: "secret", Regex: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}|https://hooks\.slack\.com/services/T[A-Z0-9]+/B[A-Z0-9]+/[A-Za-z0-9]+`)},
+			regexRule{Name: "stripe_key", DisplayName: "Stripe keys", Confidence: "high", Category: "secret", Regex: regexp.MustCompile(`\b(?:sk|rk)_(?:live|test)_[A-Za-z0-9]{24,}\b`)},
+			regexRule{Name: "private_key", DisplayName: "private keys", Confidence: "high", Category: "secret", Severity: "critical", Regex: regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |ENCRYPTED |PGP )?PRIVATE KEY(?: BLOCK)?-----`)},
+			regexRule{Name: "url_password", DisplayName: "URLs with passwords", Confidence: "high", Category: "secret", Regex: regexp.MustCompile(`(?i)\b(?:https?|ftp)(?::|%3A)` + urlPassword.String())},
+			regexRule{Name: "database_url", DisplayName: "database connection strings", Confidence: "high", Category: "secret", Severity: "critical", Regex: regexp.MustCompile(`(?i)\b(?:postgres(?:ql)?|mysql|mariadb|mongodb(?:\+srv)?|rediss?|sqlserver|oracle|amqps?)://[^\s:/@]+:[^\s@]+@|\b(?:server|data source|host)=[^;]+;.*\b(?:password|pwd)=[^;\s]+`)},
+		},
+		NameRules: []nameRule{
+			nameRule{Name: "api_key", DisplayName: "API keys", Category: "secret", ColumnNames: []string{"apikey", "apisecret", "secretkey", "clientsecret", "privatekey"}},
+		},
+		EntropyRules: []entropyRule{
+			entropyRule{Name: "high_entropy", DisplayName: "high entropy strings", MinLength: 24, MinEntropy: 4.0, Category: "secret"},
+		},
+	},
+	"uk": rulePack{
+		RegexRules: []regexRule{
+			regexRule{Name: "uk_nino", DisplayName: "UK National Insurance numbers", Severity: "high", Regex: regexp.MustCompile(`\b[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z]\s?\d{2}\s?\d{2}\s?\d{2}\s?[A-D]\b`), Validator: validNINO},
+			ibanRule,
+		},
+		NameRules: []nameRule{
+			nameRule{Name: "uk_nino", DisplayName: "UK National Insurance numbers", Severity: "high", ColumnNames: []string{"nino", "ninumber", "nationalinsurancenumber"}},
+			ibanNameRule,
+		},
+	},
 }
@@ -0,0 +1,347 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// options shared by the rules subcommands
+type RulesOpts struct {
+	Only           string
+	Except         string
+	OnlyCategory   string
+	ExceptCategory string
+	Patterns       []string
+	RulesFile      string
+	RulePack       string
+}
+
+// summary of a rule of any kind, with details for explain
+type ruleInfo struct {
+	Kind        string
+	Name        string
+	DisplayName string
+	Confidence  string
+	Category    string
+	Severity    string
+	Tags        []string
+	Columns     string
+	Details     [][2]string
+}
+
+func (opts RulesOpts) matchConfig() (MatchConfig, error) {
+	return loadMatchConfig(opts.Only, opts.Except, opts.OnlyCategory, opts.ExceptCategory, opts.Patterns, opts.RulesFile, opts.RulePack)
+}
+
+func ListRules(opts RulesOpts) error {
+	matchConfig, err := opts.matchConfig()
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "KIND\tNAME\tDISPLAY NAME\tCONFIDENCE\tCATEGORY\tCOLUMNS")
+	for _, info := range makeRuleInfos(&matchConfig) {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", info.Kind, info.Name, info.DisplayName, info.Confidence, info.Category, info.Columns)
+	}
+	return writer.Flush()
+}
+
+func ExplainRule(name string, opts RulesOpts) error {
+	matchConfig, err := opts.matchConfig()
+	if err != nil {
+		return err
+	}
+	err = updateRules(&matchConfig, name, false)
+	if err != nil {
+		return err
+	}
+
+	for i, info := range makeRuleInfos(&matchConfig) {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		fields := [][2]string{
+			{"kind", info.Kind},
+			{"name", info.Name},
+			{"display name", info.DisplayName},
+			{"confidence", info.Confidence},
+			{"category", info.Category},
+			{"severity", info.Severity},
+			{"tags", strings.Join(info.Tags, ", ")},
+		}
+		if info.Columns != "-" {
+			fields = append(fields, [2]string{"columns", info.Columns})
+		}
+		fields = append(fields, info.Details...)
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+		for _, field := range fields {
+			if field[1] != "" {
+				fmt.Fprintf(writer, "%s:\t%s\n", field[0], field[1])
+			}
+		}
+		err := writer.Flush()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checks each value on its own (or all values as a column when column is set)
+// and shows which rules match and why candidates were rejected
+func TestRules(name string, values []string, column string, opts RulesOpts) error {
+	matchConfig, err := opts.matchConfig()
+	if err != nil {
+		return err
+	}
+	err = updateRules(&matchConfig, name, false)
+	if err != nil {
+		return err
+	}
+
+	matchFinder := NewMatchFinder(&matchConfig)
+
+	if column != "" {
+		matchList := matchFinder.CheckTableData(table{}, &tableData{[]string{column}, [][]string{values}, nil})
+		fmt.Println(column)
+		printTestMatches(matchList)
+		if len(matchList) == 0 {
+			fmt.Println("  no match")
+		}
+		return nil
+	}
+
+	for _, v := range values {
+		matchFinder.Clear()
+		matchFinder.ScanValues([]string{v})
+		matchList := matchFinder.CheckMatches("", true)
+
+		fmt.Println(v)
+		printTestMatches(matchList)
+		rejections := matchFinder.rejectedCandidates(v, matchList)
+		for _, rejection := range rejections {
+			fmt.Printf("  rejected %s (%s)\n", rejection[0], rejection[1])
+		}
+		if len(matchList) == 0 && len(rejections) == 0 {
+			fmt.Println("  no match")
+		}
+	}
+	return nil
+}
+
+func printTestMatches(matchList []ruleMatch) {
+	for _, match := range matchList {
+		str := match.RuleName + ", " + match.MatchType + " match, " + confidenceStr(match)
+		if len(match.Countries) > 0 {
+			str = str + ", country " + strings.Join(match.Countries, ", ")
+		}
+		if len(match.Keywords) > 0 {
+			str = str + ", near " + strings.Join(match.Keywords, ", ")
+		}
+		description := fmt.Sprintf("  found %s (%s)", match.DisplayName, str)
+		if match.MatchType == "value" {
+			description = description + ": " + strings.Join(unique(match.MatchedData), ", ")
+		}
+		fmt.Println(description)
+	}
+}
+
+// candidates for regex and keyword rules that did not match, with the reason
+// candidates matched by another rule with the same name are skipped,
+// and the same reason from the regex and keyword rules is only shown once
+func (a *MatchFinder) rejectedCandidates(v string, matchList []ruleMatch) [][2]string {
+	matched := make(map[string]bool)
+	for _, match := range matchList {
+		for _, value := range match.MatchedData {
+			matched[match.RuleName+"\x00"+value] = true
+		}
+	}
+
+	rejections := [][2]string{}
+	seen := make(map[[2]string]bool)
+	reject := func(ruleName string, candidate string, reason string) {
+		rejection := [2]string{candidate, ruleName + ": " + reason}
+		if !matched[ruleName+"\x00"+candidate] && !seen[rejection] {
+			rejections = append(rejections, rejection)
+			seen[rejection] = true
+		}
+	}
+
+	for _, rule := range a.matchConfig.RegexRules {
+		allowlists := a.ruleAllowlists(rule.Name)
+		valid := make(map[string]bool)
+		for _, match := range filterAllowlisted(allowlists, v, rule.findValidMatches) {
+			valid[match] = true
+		}
+
+		for _, candidate := range unique(rule.Regex.FindAllString(v, -1)) {
+			if rule.Validator != nil && !rule.Validator(candidate) {
+				reject(rule.Name, candidate, "failed "+funcName(rule.Validator))
+			} else if !valid[candidate] {
+				reject(rule.Name, candidate, "allowlisted")
+			} else if rule.ColumnValidator != nil {
+				reject(rule.Name, candidate, "failed "+funcName(rule.ColumnValidator))
+			}
+		}
+	}
+
+	for _, rule := range a.matchConfig.KeywordRules {
+		allowlists := a.ruleAllowlists(rule.Name)
+		found := make(map[string]bool)
+		for _, match := range rule.findMatches(maskValue(allowlists, v)) {
+			found[match.Value] = true
+		}
+
+		for _, candidate := range unique(rule.Regex.FindAllString(v, -1)) {
+			if rule.Validator != nil && !rule.Validator(candidate) {
+				reject(rule.Name, candidate, "failed "+funcName(rule.Validator))
+			} else if !rule.Keywords.MatchString(v) {
+				reject(rule.Name, candidate, "no keyword")
+			} else if !found[candidate] {
+				reject(rule.Name, candidate, fmt.Sprintf("not within %d characters of a keyword", rule.Distance))
+			} else if allowlisted(allowlists, candidate) {
+				reject(rule.Name, candidate, "allowlisted")
+			}
+		}
+	}
+
+	return rejections
+}
+
+// rules in the order they are checked
+func makeRuleInfos(matchConfig *MatchConfig) []ruleInfo {
+	infos := []ruleInfo{}
+
+	for _, rule := range matchConfig.RegexRules {
+		confidence := rule.Confidence
+		if confidence == "" {
+			confidence = "variable"
+		}
+		infos = append(infos, ruleInfo{
+			Kind: "regex", Name: rule.Name, DisplayName: rule.DisplayName, Confidence: confidence,
+			Category: ruleCategory(rule.Category), Severity: ruleSeverity(rule.Category, rule.Severity), Tags: ruleTags(rule.Category, rule.Tags),
+			Columns: "-",
+			Details: [][2]string{
+				{"pattern", rule.Regex.String()},
+				{"validator", funcName(rule.Validator)},
+				{"column validator", funcName(rule.ColumnValidator)},
+				{"max confidence", rule.MaxConfidence},
+				{"value confidence", funcName(rule.ValueConfidence)},
+				{"country", funcName(rule.Country)},
+			},
+		})
+	}
+
+	for _, rule := range matchConfig.NameRules {
+		matchMode := rule.MatchMode
+		if matchMode == "" {
+			matchMode = "token"
+		}
+		infos = append(infos, ruleInfo{
+			Kind: "name", Name: rule.Name, DisplayName: rule.DisplayName, Confidence: "medium",
+			Category: ruleCategory(rule.Category), Severity: ruleSeverity(rule.Category, rule.Severity), Tags: ruleTags(rule.Category, rule.Tags),
+			Columns: strings.Join(rule.ColumnNames, ", "),
+			Details: [][2]string{
+				{"match", matchMode},
+				{"exclude", strings.Join(rule.Exclude, ", ")},
+				{"required columns", strings.Join(rule.RequiredColumns, ", ")},
+				{"validator", funcName(rule.Validator)},
+			},
+		})
+	}
+
+	for _, rule := range matchConfig.MultiNameRules {
+		groups := make([]string, len(rule.ColumnNames))
+		for i, group := range rule.ColumnNames {
+			groups[i] = strings.Join(group, ", ")
+		}
+		confidence := "medium"
+		if rule.Precision != nil {
+			confidence = "variable"
+		}
+		infos = append(infos, ruleInfo{
+			Kind: "multi_name", Name: rule.Name, DisplayName: rule.DisplayName, Confidence: confidence,
+			Category: ruleCategory(rule.Category), Severity: ruleSeverity(rule.Category, rule.Severity), Tags: ruleTags(rule.Category, rule.Tags),
+			Columns: strings.Join(groups, " + "),
+			Details: [][2]string{
+				{"validator", funcName(rule.Validator)},
+				{"precision", funcName(rule.Precision)},
+			},
+		})
+	}
+
+	for _, rule := range matchConfig.TokenRules {
+		infos = append(infos, ruleInfo{
+			Kind: "token", Name: rule.Name, DisplayName: rule.DisplayName, Confidence: "variable",
+			Category: ruleCategory(rule.Category), Severity: ruleSeverity(rule.Category, rule.Severity), Tags: ruleTags(rule.Category, rule.Tags),
+			Columns: "-",
+			Details: [][2]string{
+				{"tokens", ruleTokens(rule)},
+				{"paired rule", rule.PairedRule},
+			},
+		})
+	}
+
+	for _, rule := range matchConfig.EntropyRules {
+		infos = append(infos, ruleInfo{
+			Kind: "entropy", Name: rule.Name, DisplayName: rule.DisplayName, Confidence: "variable",
+			Category: ruleCategory(rule.Category), Severity: ruleSeverity(rule.Category, rule.Severity), Tags: ruleTags(rule.Category, rule.Tags),
+			Columns: "-",
+			Details: [][2]string{
+				{"min length", fmt.Sprintf("%d", rule.MinLength)},
+				{"min entropy", fmt.Sprintf("%g", rule.MinEntropy)},
+			},
+		})
+	}
+
+	for _, rule := range matchConfig.KeywordRules {
+		infos = append(infos, ruleInfo{
+			Kind: "keyword", Name: rule.Name, DisplayName: rule.DisplayName, Confidence: "high",
+			Category: ruleCategory(rule.Category), Severity: ruleSeverity(rule.Category, rule.Severity), Tags: ruleTags(rule.Category, rule.Tags),
+			Columns: "-",
+			Details: [][2]string{
+				{"pattern", rule.Regex.String()},
+				{"keywords", rule.Keywords.String()},
+				{"distance", fmt.Sprintf("%d", rule.Distance)},
+				{"validator", funcName(rule.Validator)},
+			},
+		})
+	}
+
+	return infos
+}
+
+// the full dictionary, so users can check if a word is included
+func ruleTokens(rule tokenRule) string {
+	tokens := []string{}
+	for _, token := range rule.Tokens.ToSlice() {
+		tokens = append(tokens, token.(string))
+	}
+	sort.Strings(tokens)
+	return strings.Join(tokens, ", ")
+}
+
+// name of a validator or other function, like validSSN
+func funcName(f interface{}) string {
+	value := reflect.ValueOf(f)
+	if value.Kind() != reflect.Func || value.IsNil() {
+		return ""
+	}
+
+	name := runtime.FuncForPC(value.Pointer()).Name()
+	if i := strings.LastIndex(name, "/"); i != -1 {
+		name = name[i+1:]
+	}
+	if i := strings.Index(name, "."); i != -1 {
+		name = name[i+1:]
+	}
+	return name
+}
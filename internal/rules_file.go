@@ -0,0 +1,238 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	mapset "github.com/deckarep/golang-set"
+	"gopkg.in/yaml.v3"
+)
+
+// YAML is a superset of JSON, so the same parser handles both formats
+type rulesFile struct {
+	Rules      []ruleDefinition      `yaml:"rules"`
+	Allowlists []allowlistDefinition `yaml:"allowlists"`
+}
+
+type ruleDefinition struct {
+	Kind         string     `yaml:"kind"`
+	Name         string     `yaml:"name"`
+	DisplayName  string     `yaml:"display_name"`
+	Confidence   string     `yaml:"confidence"`
+	Pattern      string     `yaml:"pattern"`
+	ColumnNames  []string   `yaml:"column_names"`
+	ColumnGroups [][]string `yaml:"column_groups"`
+	Match        string     `yaml:"match"`
+	Exclude      []string   `yaml:"exclude"`
+	Tokens       []string   `yaml:"tokens"`
+	MinLength    int        `yaml:"min_length"`
+	MinEntropy   float64    `yaml:"min_entropy"`
+	Keywords     []string   `yaml:"keywords"`
+	Distance     int        `yaml:"distance"`
+	Category     string     `yaml:"category"`
+	Severity     string     `yaml:"severity"`
+	Tags         []string   `yaml:"tags"`
+}
+
+// an empty rule applies to all rules
+type allowlistDefinition struct {
+	Rule        string   `yaml:"rule"`
+	Values      []string `yaml:"values"`
+	Domains     []string `yaml:"domains"`
+	Patterns    []string `yaml:"patterns"`
+	Identifiers []string `yaml:"identifiers"`
+}
+
+func loadRulesFile(matchConfig *MatchConfig, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var file rulesFile
+	err = yaml.Unmarshal(data, &file)
+	if err != nil {
+		return fmt.Errorf("Invalid rules file: %s\n%s", path, err)
+	}
+
+	for _, definition := range file.Rules {
+		err := addRuleDefinition(matchConfig, definition)
+		if err != nil {
+			return fmt.Errorf("Invalid rules file: %s\n%s", path, err)
+		}
+	}
+
+	// after rules so allowlists can refer to them
+	for _, definition := range file.Allowlists {
+		err := addAllowlistDefinition(matchConfig, definition)
+		if err != nil {
+			return fmt.Errorf("Invalid rules file: %s\n%s", path, err)
+		}
+	}
+
+	return nil
+}
+
+func addRuleDefinition(matchConfig *MatchConfig, definition ruleDefinition) error {
+	name := definition.Name
+	if name == "" {
+		return fmt.Errorf("Missing name for rule")
+	}
+
+	displayName := definition.DisplayName
+	if displayName == "" {
+		displayName = name
+	}
+
+	category := definition.Category
+	if category != "" && !validCategory(category) {
+		return fmt.Errorf("Invalid category for rule: %s\nValid categories are %s", name, strings.Join(categories, ", "))
+	}
+	if definition.Severity != "" && !validSeverity(definition.Severity) {
+		return fmt.Errorf("Invalid severity for rule: %s\nValid severities are %s", name, strings.Join(severities, ", "))
+	}
+
+	switch definition.Kind {
+	case "regex":
+		if definition.Pattern == "" {
+			return fmt.Errorf("Missing pattern for rule: %s", name)
+		}
+		if !validConfidence(definition.Confidence) {
+			return fmt.Errorf("Invalid confidence for rule: %s", name)
+		}
+		if hasRegexRule(matchConfig, name) {
+			return fmt.Errorf("Duplicate rule: %s", name)
+		}
+		regex, err := regexp.Compile(definition.Pattern)
+		if err != nil {
+			return fmt.Errorf("Invalid pattern for rule: %s\n%s", name, err)
+		}
+		matchConfig.RegexRules = append(matchConfig.RegexRules, regexRule{Name: name, DisplayName: displayName, Category: category, Severity: definition.Severity, Tags: definition.Tags, Confidence: definition.Confidence, Regex: regex})
+	case "name":
+		if len(definition.ColumnNames) == 0 {
+			return fmt.Errorf("Missing column_names for rule: %s", name)
+		}
+		if hasNameRule(matchConfig, name) {
+			return fmt.Errorf("Duplicate rule: %s", name)
+		}
+		if !validMatchMode(definition.Match) {
+			return fmt.Errorf("Invalid match for rule: %s\nValid matches are contains, exact, prefix, suffix, token", name)
+		}
+		matchConfig.NameRules = append(matchConfig.NameRules, nameRule{Name: name, DisplayName: displayName, Category: category, Severity: definition.Severity, Tags: definition.Tags, ColumnNames: normalizeColumnNames(definition.ColumnNames), MatchMode: definition.Match, Exclude: normalizeColumnNames(definition.Exclude)})
+	case "multi_name":
+		if len(definition.ColumnGroups) < 2 {
+			return fmt.Errorf("Expected at least 2 column_groups for rule: %s", name)
+		}
+		for _, rule := range matchConfig.MultiNameRules {
+			if rule.Name == name {
+				return fmt.Errorf("Duplicate rule: %s", name)
+			}
+		}
+		columnGroups := make([][]string, len(definition.ColumnGroups))
+		for i, group := range definition.ColumnGroups {
+			columnGroups[i] = normalizeColumnNames(group)
+		}
+		matchConfig.MultiNameRules = append(matchConfig.MultiNameRules, multiNameRule{Name: name, DisplayName: displayName, Category: category, Severity: definition.Severity, Tags: definition.Tags, ColumnNames: columnGroups})
+	case "token":
+		if len(definition.Tokens) == 0 {
+			return fmt.Errorf("Missing tokens for rule: %s", name)
+		}
+		for _, rule := range matchConfig.TokenRules {
+			if rule.Name == name {
+				return fmt.Errorf("Duplicate rule: %s", name)
+			}
+		}
+		tokens := mapset.NewSet()
+		for _, token := range definition.Tokens {
+			tokens.Add(foldAccents(token))
+		}
+		matchConfig.TokenRules = append(matchConfig.TokenRules, tokenRule{Name: name, DisplayName: displayName, Category: category, Severity: definition.Severity, Tags: definition.Tags, Tokens: tokens})
+	case "entropy":
+		if definition.MinLength < 1 {
+			return fmt.Errorf("Missing min_length for rule: %s", name)
+		}
+		if definition.MinEntropy <= 0 {
+			return fmt.Errorf("Missing min_entropy for rule: %s", name)
+		}
+		if hasEntropyRule(matchConfig, name) {
+			return fmt.Errorf("Duplicate rule: %s", name)
+		}
+		matchConfig.EntropyRules = append(matchConfig.EntropyRules, entropyRule{Name: name, DisplayName: displayName, Category: category, Severity: definition.Severity, Tags: definition.Tags, MinLength: definition.MinLength, MinEntropy: definition.MinEntropy})
+	case "keyword":
+		if definition.Pattern == "" {
+			return fmt.Errorf("Missing pattern for rule: %s", name)
+		}
+		if len(definition.Keywords) == 0 {
+			return fmt.Errorf("Missing keywords for rule: %s", name)
+		}
+		for _, rule := range matchConfig.KeywordRules {
+			if rule.Name == name {
+				return fmt.Errorf("Duplicate rule: %s", name)
+			}
+		}
+		regex, err := regexp.Compile(definition.Pattern)
+		if err != nil {
+			return fmt.Errorf("Invalid pattern for rule: %s\n%s", name, err)
+		}
+		distance := definition.Distance
+		if distance == 0 {
+			distance = 20
+		}
+		matchConfig.KeywordRules = append(matchConfig.KeywordRules, keywordRule{Name: name, DisplayName: displayName, Category: category, Severity: definition.Severity, Tags: definition.Tags, Keywords: keywordsRegex(definition.Keywords...), Regex: regex, Distance: distance})
+	default:
+		return fmt.Errorf("Invalid kind for rule: %s\nValid kinds are entropy, keyword, multi_name, name, regex, token", name)
+	}
+
+	return nil
+}
+
+func addAllowlistDefinition(matchConfig *MatchConfig, definition allowlistDefinition) error {
+	rule := definition.Rule
+	if rule != "" && !makeValidNames(matchConfig)[rule] {
+		return fmt.Errorf("Invalid rule for allowlist: %s", rule)
+	}
+
+	list := allowlist{Rule: rule, Values: definition.Values}
+	for _, domain := range definition.Domains {
+		list.Domains = append(list.Domains, strings.TrimLeft(strings.ToLower(domain), "@."))
+	}
+	for _, pattern := range definition.Patterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("Invalid pattern for allowlist: %s\n%s", pattern, err)
+		}
+		list.Patterns = append(list.Patterns, regex)
+	}
+	for _, identifier := range definition.Identifiers {
+		list.Identifiers = append(list.Identifiers, identifierPattern(identifier))
+	}
+
+	if len(list.Values) == 0 && len(list.Domains) == 0 && len(list.Patterns) == 0 && len(list.Identifiers) == 0 {
+		if rule == "" {
+			return fmt.Errorf("Empty allowlist")
+		}
+		return fmt.Errorf("Empty allowlist for rule: %s", rule)
+	}
+
+	matchConfig.Allowlists = append(matchConfig.Allowlists, list)
+	return nil
+}
+
+// match the format of nameRules so both under_score and camelCase work
+func normalizeColumnNames(columnNames []string) []string {
+	normalized := make([]string, len(columnNames))
+	for i, col := range columnNames {
+		normalized[i] = normalizeColumnName(col)
+	}
+	return normalized
+}
+
+func validMatchMode(match string) bool {
+	return match == "" || match == "token" || match == "exact" || match == "prefix" || match == "suffix" || match == "contains"
+}
+
+func validConfidence(confidence string) bool {
+	return confidence == "" || confidence == "high" || confidence == "medium" || confidence == "low"
+}
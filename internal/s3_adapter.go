@@ -84,5 +84,5 @@ func (a S3Adapter) FindFileMatches(filename string, matchFinder *MatchFinder) er
 		return err
 	}
 
-	return processFile(bufio.NewReader(resp.Body), matchFinder)
+	return processFile(bufio.NewReader(resp.Body), matchFinder, false)
 }
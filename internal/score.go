@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"math"
+	"strings"
+)
+
+// scores are between 0 and 1, and confidence labels are buckets of them
+func confidenceLabel(score float64) string {
+	if score >= 0.7 {
+		return "high"
+	} else if score >= 0.4 {
+		return "medium"
+	}
+	return "low"
+}
+
+// rules and values with a fixed confidence, adjusted slightly by the match ratio
+func fixedScore(confidence string, ratio float64) float64 {
+	switch confidence {
+	case "high":
+		return 0.8 + 0.2*ratio
+	case "medium":
+		return 0.5 + 0.15*ratio
+	}
+	return 0.1 + 0.25*ratio
+}
+
+// more than half of values matching is high confidence
+func ratioScore(ratio float64) float64 {
+	if ratio > 0.5 {
+		return 0.7 + 0.6*(ratio-0.5)
+	}
+	return 0.7 * ratio
+}
+
+// values passing a strict validator (like a checksum) are stronger evidence,
+// so a smaller share of them is high confidence
+// a single value in a large file stays low
+func validatedScore(ratio float64) float64 {
+	return ratioScore(math.Min(1, 2*ratio))
+}
+
+// candidates failing the validator (like checksums) lower the score
+func validatorFactor(passRate float64) float64 {
+	return 0.5 + 0.5*passRate
+}
+
+// share of candidates (matches before validation) that pass the validator
+func validatorPassRate(rule regexRule, lists []allowlist, lines []string) float64 {
+	if rule.Validator == nil {
+		return 1
+	}
+
+	candidateRule := rule
+	candidateRule.Validator = nil
+	candidates := 0
+	valid := 0
+	for _, v := range lines {
+		for _, match := range filterAllowlisted(lists, v, candidateRule.findValidMatches) {
+			candidates++
+			if rule.Validator(match) {
+				valid++
+			}
+		}
+	}
+	if candidates == 0 {
+		return 1
+	}
+	return float64(valid) / float64(candidates)
+}
+
+// the same few values repeated (like placeholders) are less likely to be real data
+func uniquenessFactor(values []string) float64 {
+	if len(values) < 10 {
+		return 1
+	}
+	ratio := float64(len(unique(values))) / float64(len(values))
+	if ratio >= 0.1 {
+		return 1
+	}
+	return 0.5 + 5*ratio
+}
+
+// independent evidence for the same rule, like a phone column with phone numbers
+func combineScores(a float64, b float64) float64 {
+	return 1 - (1-a)*(1-b)
+}
+
+var booleanValues = map[string]bool{"true": true, "false": true, "t": true, "f": true, "yes": true, "no": true, "y": true, "n": true, "0": true, "1": true}
+
+// name matches start at medium confidence, and sampled values that
+// contradict the name (like a zip column of booleans) lower it to low or zero
+// columns with only a few values are not checked
+func (rule nameRule) valueScore(values []string) float64 {
+	score := fixedScore("medium", 0)
+
+	nonEmpty := []string{}
+	for _, v := range values {
+		if v != "" {
+			nonEmpty = append(nonEmpty, v)
+		}
+	}
+	if len(nonEmpty) < 5 {
+		return score
+	}
+
+	booleanCount := 0
+	validCount := 0
+	for _, v := range nonEmpty {
+		if booleanValues[strings.ToLower(strings.TrimSpace(v))] {
+			booleanCount++
+		}
+		if rule.Validator == nil || rule.Validator(v) {
+			validCount++
+		}
+	}
+
+	booleanRatio := float64(booleanCount) / float64(len(nonEmpty))
+	passRate := float64(validCount) / float64(len(nonEmpty))
+	if booleanRatio >= 0.9 || passRate < 0.1 {
+		return 0
+	} else if passRate < 0.5 {
+		return fixedScore("low", 0.5)
+	}
+	return score
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(math.Max(0, math.Min(1, score))*100) / 100
+}
+
+func matchRatio(matchCount int, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return math.Min(1, float64(matchCount)/float64(count))
+}
+
+// sets labels and category defaults, and drops matches below the minimum
+func (a *MatchFinder) finishMatches(matchList []ruleMatch) []ruleMatch {
+	newMatchList := []ruleMatch{}
+	for _, match := range a.removeAllowlistedIdentifiers(matchList) {
+		match.setCategoryDefaults()
+		match.Score = roundScore(match.Score)
+		match.Confidence = confidenceLabel(match.Score)
+		if match.Score >= a.matchConfig.MinConfidence {
+			newMatchList = append(newMatchList, match)
+		}
+	}
+	return newMatchList
+}
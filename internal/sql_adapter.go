@@ -153,7 +153,7 @@ func (a SqlAdapter) FetchTableData(table table, limit int) (*tableData, error) {
 		}
 	}
 
-	return &tableData{columnNames, columnValues}, nil
+	return &tableData{columnNames, columnValues, nil}, nil
 }
 
 // helpers
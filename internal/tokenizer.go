@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// letters that NFKD does not decompose
+var foldReplacer = strings.NewReplacer("ß", "ss", "æ", "ae", "œ", "oe", "ø", "o", "ł", "l", "đ", "d", "þ", "th", "ı", "i")
+
+// lowercases and removes accents, so Muñoz becomes munoz
+func foldAccents(v string) string {
+	// transformers are not safe for concurrent use
+	folder := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(folder, strings.ToLower(v))
+	if err != nil {
+		return strings.ToLower(v)
+	}
+	return foldReplacer.Replace(folded)
+}
+
+// scripts without spaces between words
+func isCJK(c rune) bool {
+	return unicode.In(c, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// splits on anything other than letters, digits, and underscores
+// apostrophes are removed so O'Brien becomes obrien
+// CJK characters are kept apart from other letters
+func tokenize(v string) []string {
+	tokens := []string{}
+	var token strings.Builder
+	tokenCJK := false
+
+	for _, c := range foldAccents(v) {
+		if c == '\'' || c == '’' {
+			continue
+		}
+
+		if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' {
+			cjk := isCJK(c)
+			if token.Len() > 0 && cjk != tokenCJK {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+			token.WriteRune(c)
+			tokenCJK = cjk
+		} else if token.Len() > 0 {
+			tokens = append(tokens, token.String())
+			token.Reset()
+		}
+	}
+
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+	return tokens
+}
+
+// byte offset of the first token in v that tokenizes to token, or -1
+// v is split the same way as tokenize, but before folding, so offsets
+// point to the original text (like Muñoz for munoz)
+func tokenIndex(v string, token string) int {
+	start := -1
+	startCJK := false
+	matches := func(end int) bool {
+		tokens := tokenize(v[start:end])
+		return len(tokens) == 1 && tokens[0] == token
+	}
+
+	for i, c := range v {
+		if c == '\'' || c == '’' || unicode.Is(unicode.Mn, c) {
+			continue
+		}
+
+		if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' {
+			cjk := isCJK(c)
+			if start != -1 && cjk != startCJK {
+				if matches(i) {
+					return start
+				}
+				start = -1
+			}
+			if start == -1 {
+				start = i
+				startCJK = cjk
+			}
+		} else if start != -1 {
+			if matches(i) {
+				return start
+			}
+			start = -1
+		}
+	}
+
+	if start != -1 && matches(len(v)) {
+		return start
+	}
+	return -1
+}
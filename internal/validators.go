@@ -0,0 +1,482 @@
+package internal
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+)
+
+// validators receive a single regex match and return false for values
+// that match the format but cannot be real (bad checksums, reserved ranges)
+
+func digitsOnly(v string) string {
+	var sb strings.Builder
+	for _, c := range v {
+		if c >= '0' && c <= '9' {
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
+
+// https://en.wikipedia.org/wiki/Luhn_algorithm
+func luhnChecksum(digits string) bool {
+	if digits == "" {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func validLuhn(v string) bool {
+	return luhnChecksum(digitsOnly(v))
+}
+
+// https://www.ssa.gov/employer/randomization.html
+func validSSN(v string) bool {
+	digits := digitsOnly(v)
+	if len(digits) != 9 {
+		return false
+	}
+
+	area := digits[0:3]
+	group := digits[3:5]
+	serial := digits[5:9]
+
+	if area == "000" || area == "666" || area[0] == '9' {
+		return false
+	}
+	return group != "00" && serial != "0000"
+}
+
+func validIPv4(v string) bool {
+	octets := strings.Split(v, ".")
+	if len(octets) != 4 {
+		return false
+	}
+	for _, octet := range octets {
+		n, err := strconv.Atoi(octet)
+		if err != nil || n > 255 {
+			return false
+		}
+	}
+	return true
+}
+
+func validMac(v string) bool {
+	hex := strings.ToLower(strings.NewReplacer(":", "", "%3A", "", "%3a", "").Replace(v))
+	return len(hex) == 12 && hex != "000000000000"
+}
+
+func validIPv6(v string) bool {
+	addr, err := netip.ParseAddr(v)
+	// IPv4-mapped addresses (::ffff:10.0.0.1) are matched by the ip rule
+	if err != nil || !addr.Is6() || addr.IsUnspecified() || addr.Is4In6() {
+		return false
+	}
+
+	// require at least one full-size group and two groups in total
+	// to rule out timestamps (12:30:45), MAC-like strings (aa:bb:cc:dd:ee:ff),
+	// and scope operators in code (std::vector)
+	groups := 0
+	longGroup := false
+	for _, group := range strings.Split(strings.SplitN(v, "%", 2)[0], ":") {
+		if group == "" {
+			continue
+		}
+		groups++
+		if len(group) > 2 {
+			longGroup = true
+		}
+	}
+	return groups >= 2 && longGroup
+}
+
+var ipv6Documentation = netip.MustParsePrefix("2001:db8::/32")
+
+// global addresses identify a client, while link-local, private,
+// and documentation addresses are only meaningful within a network or as examples
+func ipv6Confidence(v string) string {
+	addr, err := netip.ParseAddr(v)
+	if err != nil {
+		return "low"
+	}
+	addr = addr.WithZone("")
+
+	// 2000::/3 is the only range currently allocated for global unicast
+	if addr.As16()[0]&0xe0 == 0x20 && !addr.IsPrivate() && !ipv6Documentation.Contains(addr) {
+		return "high"
+	}
+	return "low"
+}
+
+// https://en.wikipedia.org/wiki/International_Bank_Account_Number#Validating_the_IBAN
+func validIBAN(v string) bool {
+	iban := strings.ToUpper(strings.Replace(v, " ", "", -1))
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	return mod97(iban[4:]+iban[0:4]) == 1
+}
+
+// letters are converted to numbers (A = 10, B = 11, ...)
+func mod97(v string) int {
+	remainder := 0
+	for _, c := range v {
+		if c >= '0' && c <= '9' {
+			remainder = (remainder*10 + int(c-'0')) % 97
+		} else if c >= 'A' && c <= 'Z' {
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		} else {
+			return -1
+		}
+	}
+	return remainder
+}
+
+// https://www.gov.uk/hmrc-internal-manuals/national-insurance-manual/nim39110
+func validNINO(v string) bool {
+	prefix := strings.ToUpper(v[0:2])
+	return !stringInSlice(prefix, []string{"BG", "GB", "KN", "NK", "NT", "TN", "ZZ"})
+}
+
+// https://www.canada.ca/en/employment-social-development/services/sin.html
+func validSIN(v string) bool {
+	digits := digitsOnly(v)
+	return len(digits) == 9 && luhnChecksum(digits)
+}
+
+// https://www.interior.gob.es/opencms/en/servicios-al-ciudadano/tramites-y-gestiones/dni/calculo-del-digito-de-control-del-nif-nie/
+func validDNI(v string) bool {
+	id := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(v))
+	switch id[0] {
+	case 'X':
+		id = "0" + id[1:]
+	case 'Y':
+		id = "1" + id[1:]
+	case 'Z':
+		id = "2" + id[1:]
+	}
+	if len(id) != 9 {
+		return false
+	}
+
+	n, err := strconv.Atoi(id[0:8])
+	if err != nil {
+		return false
+	}
+	return "TRWAGMYFPDXBNJZSQVHLCKE"[n%23] == id[8]
+}
+
+// ISO 7064 MOD 11,10
+// https://de.wikipedia.org/wiki/Steuerliche_Identifikationsnummer
+func validGermanTaxID(v string) bool {
+	digits := digitsOnly(v)
+	if len(digits) != 11 || digits[0] == '0' {
+		return false
+	}
+
+	// exactly one digit is repeated in the first ten digits
+	counts := make(map[rune]int)
+	for _, c := range digits[0:10] {
+		counts[c]++
+	}
+	repeated := 0
+	for _, count := range counts {
+		if count > 3 {
+			return false
+		} else if count > 1 {
+			repeated++
+		}
+	}
+	if repeated != 1 {
+		return false
+	}
+
+	product := 10
+	for _, c := range digits[0:10] {
+		sum := (int(c-'0') + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = (sum * 2) % 11
+	}
+	check := 11 - product
+	if check == 10 {
+		check = 0
+	}
+	return check == int(digits[10]-'0')
+}
+
+// https://fr.wikipedia.org/wiki/Num%C3%A9ro_de_s%C3%A9curit%C3%A9_sociale_en_France
+func validINSEE(v string) bool {
+	id := strings.ToUpper(strings.Replace(v, " ", "", -1))
+	if len(id) != 15 {
+		return false
+	}
+
+	// Corsica
+	number := strings.NewReplacer("2A", "19", "2B", "18").Replace(id[0:13])
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return false
+	}
+	key, err := strconv.Atoi(id[13:15])
+	if err != nil {
+		return false
+	}
+	return int(97-n%97) == key
+}
+
+// https://pt.wikipedia.org/wiki/Cadastro_de_pessoas_f%C3%ADsicas
+func validCPF(v string) bool {
+	digits := digitsOnly(v)
+	if len(digits) != 11 || strings.Count(digits, digits[0:1]) == 11 {
+		return false
+	}
+
+	for n := 9; n <= 10; n++ {
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += int(digits[i]-'0') * (n + 1 - i)
+		}
+		check := sum * 10 % 11
+		if check == 10 {
+			check = 0
+		}
+		if check != int(digits[n]-'0') {
+			return false
+		}
+	}
+	return true
+}
+
+var verhoeffMultiplication = [10][10]int{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+	{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+	{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+	{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+	{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+	{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+	{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+	{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+	{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+}
+
+var verhoeffPermutation = [8][10]int{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+	{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+	{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+	{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+	{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+	{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+	{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+}
+
+// https://en.wikipedia.org/wiki/Verhoeff_algorithm
+func verhoeffChecksum(digits string) bool {
+	c := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		c = verhoeffMultiplication[c][verhoeffPermutation[i%8][d]]
+	}
+	return c == 0
+}
+
+// https://uidai.gov.in/
+func validAadhaar(v string) bool {
+	digits := digitsOnly(v)
+	return len(digits) == 12 && verhoeffChecksum(digits)
+}
+
+// Luhn with the 80840 prefix for US health applications
+// https://www.cms.gov/Regulations-and-Guidance/Administrative-Simplification/NationalProvIdentStand/Downloads/NPIcheckdigit.pdf
+func validNPI(v string) bool {
+	digits := digitsOnly(v)
+	return len(digits) == 10 && luhnChecksum("80840"+digits)
+}
+
+// the second character can be 9 (for business activity), so only check after it
+// https://en.wikipedia.org/wiki/DEA_number
+func validDEA(v string) bool {
+	if len(v) != 9 {
+		return false
+	}
+	digits := v[2:]
+	if digitsOnly(digits) != digits {
+		return false
+	}
+
+	d := make([]int, 7)
+	for i := range digits {
+		d[i] = int(digits[i] - '0')
+	}
+	sum := d[0] + d[2] + d[4] + 2*(d[1]+d[3]+d[5])
+	return sum%10 == d[6]
+}
+
+// 3-7-1 weights, and the first two digits are a Federal Reserve district
+// https://en.wikipedia.org/wiki/ABA_routing_transit_number
+func validABA(v string) bool {
+	digits := digitsOnly(v)
+	// the checksum of all zeros is also zero
+	if len(digits) != 9 || digits == "000000000" {
+		return false
+	}
+
+	prefix, _ := strconv.Atoi(digits[0:2])
+	if !(prefix <= 12 || (prefix >= 21 && prefix <= 32) || (prefix >= 61 && prefix <= 72) || prefix == 80) {
+		return false
+	}
+
+	weights := []int{3, 7, 1}
+	sum := 0
+	for i := range digits {
+		sum += int(digits[i]-'0') * weights[i%3]
+	}
+	return sum%10 == 0
+}
+
+// bank code, ISO 3166 country code, location code, and optional branch code
+// https://en.wikipedia.org/wiki/ISO_9362
+func validBIC(v string) bool {
+	v = strings.ToUpper(strings.Replace(v, " ", "", -1))
+	if len(v) != 8 && len(v) != 11 {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		letter := v[i] >= 'A' && v[i] <= 'Z'
+		digit := v[i] >= '0' && v[i] <= '9'
+		// bank and country codes are letters
+		if !letter && (i < 6 || !digit) {
+			return false
+		}
+	}
+	region, err := language.ParseRegion(v[4:6])
+	return err == nil && region.IsCountry() && region.String() == v[4:6]
+}
+
+// local account numbers are digits, and IBANs are used for international accounts
+func validAccountNumber(v string) bool {
+	if validIBAN(v) {
+		return true
+	}
+	compact := strings.NewReplacer(" ", "", "-", "").Replace(v)
+	digits := digitsOnly(compact)
+	return len(digits) == len(compact) && len(digits) >= 4 && len(digits) <= 17
+}
+
+var vinWeights = []int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+const vinLetters = "ABCDEFGHJKLMNPRSTUVWXYZ"
+const vinLetterValues = "12345678123457923456789"
+
+// letters are transliterated to digits and the 9th character is the check digit
+// real VINs have letters in the manufacturer code
+// https://en.wikipedia.org/wiki/Vehicle_identification_number#Check-digit_calculation
+func validVIN(v string) bool {
+	v = strings.ToUpper(v)
+	if len(v) != 17 || !hasLetter(v) || !hasDigit(v) {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		var value int
+		if c >= '0' && c <= '9' {
+			value = int(c - '0')
+		} else {
+			// I, O, and Q are not allowed
+			j := strings.IndexByte(vinLetters, c)
+			if j == -1 {
+				return false
+			}
+			value = int(vinLetterValues[j] - '0')
+		}
+		sum += value * vinWeights[i]
+	}
+
+	check := "0123456789X"[sum%11]
+	return v[8] == check
+}
+
+// ICAO 9303 check digits with 7-3-1 weights (A = 10, B = 11, ... and < = 0)
+func mrzCheckDigit(v string) byte {
+	weights := []int{7, 3, 1}
+	sum := 0
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		value := 0
+		if c >= '0' && c <= '9' {
+			value = int(c - '0')
+		} else if c >= 'A' && c <= 'Z' {
+			value = int(c-'A') + 10
+		}
+		sum += value * weights[i%3]
+	}
+	return byte('0' + sum%10)
+}
+
+// second line of a passport machine readable zone, with the document number,
+// date of birth, expiration date, and composite check digits
+// https://www.icao.int/publications/Documents/9303_p4_cons_en.pdf
+func validPassportMRZ(v string) bool {
+	if len(v) != 44 {
+		return false
+	}
+	if mrzCheckDigit(v[0:9]) != v[9] || mrzCheckDigit(v[13:19]) != v[19] || mrzCheckDigit(v[21:27]) != v[27] {
+		return false
+	}
+	if v[42] != '<' && mrzCheckDigit(v[28:42]) != v[42] {
+		return false
+	}
+	return mrzCheckDigit(v[0:10]+v[13:20]+v[21:43]) == v[43]
+}
+
+// most passport numbers are 6 to 9 letters and digits
+// (US passports are 9 digits, or a letter and 8 digits for newer ones)
+func validPassportNumber(v string) bool {
+	v = strings.TrimSpace(v)
+	if len(v) < 6 || len(v) > 9 || !hasDigit(v) {
+		return false
+	}
+	for _, c := range v {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// 7 digit min and 15 digit max, like the phone regex rule
+func validPhoneDigits(v string) bool {
+	digits := digitsOnly(v)
+	return len(digits) >= 7 && len(digits) <= 15
+}
+
+func hasLetter(v string) bool {
+	return strings.IndexFunc(v, unicode.IsLetter) != -1
+}
+
+func hasDigit(v string) bool {
+	return strings.IndexFunc(v, unicode.IsDigit) != -1
+}